@@ -0,0 +1,249 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// plan is the precomputed resolution of a binding resolver.
+type plan struct {
+	function  reflect.Value                   // function is the reflected resolver.
+	types     []reflect.Type                  // types are the resolver argument types.
+	arguments []*binding                      // arguments are the bindings of the resolver arguments, nil for In structures.
+	values    []atomic.Pointer[reflect.Value] // values are the made singleton arguments, converted to the argument types.
+	pool      sync.Pool                       // pool holds the argument slices of the resolver calls.
+}
+
+// invoke calls the resolver with the concretes of the planned argument bindings.
+// The singleton arguments are converted once and reused, and the argument slices are reused between the calls.
+func (p *plan) invoke(c Container) (interface{}, error) {
+	if len(p.arguments) == 0 {
+		return result(p.function.Call(nil))
+	}
+
+	arguments, _ := p.pool.Get().(*[]reflect.Value)
+	if arguments == nil {
+		s := make([]reflect.Value, len(p.arguments))
+		arguments = &s
+	}
+
+	var concrete interface{}
+	err := p.fill(c, *arguments)
+	if err == nil {
+		concrete, err = result(p.function.Call(*arguments))
+	}
+
+	clear(*arguments)
+	p.pool.Put(arguments)
+
+	return concrete, err
+}
+
+// fill sets the arguments to the concretes of the planned argument bindings.
+func (p *plan) fill(c Container, arguments []reflect.Value) error {
+	for i, b := range p.arguments {
+		if value := p.values[i].Load(); value != nil {
+			arguments[i] = *value
+			continue
+		}
+
+		if b == nil {
+			argument, err := c.argument(p.types[i])
+			if err != nil {
+				return err
+			}
+			arguments[i] = argument
+			continue
+		}
+
+		argument, err := b.makeValue(c, p.types[i])
+		if err != nil {
+			return err
+		}
+		arguments[i] = argument
+
+		if b.isSingleton && b.isConverted.Load() {
+			value := argument
+			p.values[i].Store(&value)
+		}
+	}
+
+	return nil
+}
+
+// copyBindings returns copies of the bindings that share the made concretes but not the binding states.
+func copyBindings(bindings map[reflect.Type]map[string]*binding) map[reflect.Type]map[string]*binding {
+	copies := map[*binding]*binding{}
+
+	var copyOf func(b *binding) *binding
+	copyOf = func(b *binding) *binding {
+		if b == nil {
+			return nil
+		}
+		if cp, exist := copies[b]; exist {
+			return cp
+		}
+
+		cp := b.clone()
+		if cp.factory != nil {
+			cp.reset() // The factory is made again, so it resolves its arguments with the Container of the copy.
+		}
+		copies[b] = cp
+		cp.source = copyOf(b.source)
+		return cp
+	}
+
+	copied := make(map[reflect.Type]map[string]*binding, len(bindings))
+	for abstraction, named := range bindings {
+		copied[abstraction] = make(map[string]*binding, len(named))
+		for name, b := range named {
+			copied[abstraction][name] = copyOf(b)
+		}
+	}
+
+	return copied
+}
+
+// Build validates the bindings and returns a read-only Container that resolves them with precomputed plans.
+// It fails if a resolver depends on an abstraction that is not bound or if there is a circular dependency.
+// The built Container holds copies of the bindings, so later changes to c do not affect it.
+// It rejects new bindings and resolves the argument bindings without looking them up again.
+func (c Container) Build() (Container, error) {
+	built := c
+	built.bindings = copyBindings(c.bindings)
+	built.overriding = false
+	built.isBuilt = true
+	built.lifecycle = &lifecycle{}
+
+	b := &builder{container: built, states: map[*binding]int{}}
+	for _, info := range built.Bindings() {
+		if err := b.visit(built.bindings[info.Type][info.Name], info.Type, info.Name); err != nil {
+			return Container{}, err
+		}
+	}
+
+	return built, nil
+}
+
+// builder validates the bindings and makes their plans.
+type builder struct {
+	container Container
+	states    map[*binding]int // states are zero for new, one for visiting, and two for visited bindings.
+	path      []string         // path is the chain of the abstractions being visited.
+}
+
+// visit validates the dependencies of the binding and makes its plan.
+func (b *builder) visit(target *binding, abstraction reflect.Type, name string) error {
+	if target.source != nil {
+		return b.visit(target.source, abstraction, name)
+	}
+
+	label := label(abstraction, name)
+
+	switch b.states[target] {
+	case 1:
+		return fmt.Errorf("container: circular dependency: %s -> %s", strings.Join(b.path, " -> "), label)
+	case 2:
+		return nil
+	}
+
+	b.states[target] = 1
+	b.path = append(b.path, label)
+	defer func() {
+		b.path = b.path[:len(b.path)-1]
+		b.states[target] = 2
+	}()
+
+	if target.resolver == nil {
+		return nil
+	}
+
+	reflectedResolver := reflect.TypeOf(target.resolver)
+	p := &plan{
+		function:  reflect.ValueOf(target.resolver),
+		types:     make([]reflect.Type, reflectedResolver.NumIn()),
+		arguments: make([]*binding, reflectedResolver.NumIn()),
+		values:    make([]atomic.Pointer[reflect.Value], reflectedResolver.NumIn()),
+	}
+
+	for i := range p.types {
+		p.types[i] = reflectedResolver.In(i)
+
+		if embeds(p.types[i], inType) {
+			if err := b.visitFields(p.types[i], target.site); err != nil {
+				return err
+			}
+			continue
+		}
+
+		dependency, _, exist := b.container.lookup(p.types[i], "")
+		if !exist {
+			return fmt.Errorf(
+				"container: no concrete found for: %s (required by %s bound at %s)",
+				p.types[i].String(), label, target.site,
+			)
+		}
+
+		if _, own := b.container.bindings[p.types[i]][""]; !own {
+			continue // The parent bindings are resolved with the parent Container (see Scope).
+		}
+
+		if err := b.visit(dependency, p.types[i], ""); err != nil {
+			return err
+		}
+		p.arguments[i] = dependency
+
+		if dependency.isSingleton && dependency.concrete != nil {
+			value := reflect.New(p.types[i]).Elem()
+			value.Set(reflect.ValueOf(dependency.concrete))
+			p.values[i].Store(&value)
+		}
+	}
+
+	target.plan = p
+
+	return nil
+}
+
+// visitFields validates the dependencies of the fields of an In structure.
+func (b *builder) visitFields(structure reflect.Type, site Site) error {
+	tagged, err := taggedFields(structure)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range tagged {
+		if field.config != "" {
+			continue
+		}
+
+		dependency, _, exist := b.container.lookup(field.t, field.name)
+		if !exist {
+			if field.optional {
+				continue
+			}
+			return fmt.Errorf(
+				"container: no concrete found for: %s field of %s (required by resolver bound at %s)",
+				field.label, structure.String(), site,
+			)
+		}
+
+		if _, own := b.container.bindings[field.t][field.name]; !own {
+			continue
+		}
+
+		if err := b.visit(dependency, field.t, field.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsBuilt checks if the Container is built and read-only.
+func (c Container) IsBuilt() bool {
+	return c.isBuilt
+}
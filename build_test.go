@@ -0,0 +1,305 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Service struct {
+	s Shape
+	d Database
+}
+
+func TestContainer_Build(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.TransientLazy(func() (Database, error) {
+		return &MySQL{}, nil
+	})
+	assert.NoError(t, err)
+
+	err = c.TransientLazy(func(s Shape, d Database) *Service {
+		return &Service{s: s, d: d}
+	})
+	assert.NoError(t, err)
+
+	built, err := c.Build()
+	assert.NoError(t, err)
+	assert.True(t, built.IsBuilt())
+	assert.False(t, c.IsBuilt())
+
+	var s1, s2 *Service
+	assert.NoError(t, built.Resolve(&s1))
+	assert.NoError(t, built.Resolve(&s2))
+	assert.NotSame(t, s1, s2)
+	assert.Same(t, s1.s, s2.s)
+	assert.IsType(t, &MySQL{}, s1.d)
+
+	err = built.Call(func(s *Service) {
+		assert.Equal(t, 13, s.s.GetArea())
+	})
+	assert.NoError(t, err)
+}
+
+func TestContainer_Build_It_Should_Be_Read_Only(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	built, err := c.Build()
+	assert.NoError(t, err)
+
+	err = built.Singleton(func() Database {
+		return &MySQL{}
+	})
+	assert.EqualError(t, err, "container: the container is built and its bindings cannot be changed")
+
+	err = built.Instance(&MySQL{})
+	assert.Error(t, err)
+
+	err = built.Factory(1, func(a int) *Circle {
+		return &Circle{a: a}
+	})
+	assert.Error(t, err)
+
+	err = container.Unbind[Shape](built, "")
+	assert.Error(t, err)
+
+	err = container.Replace[Shape](built, "", func() Shape {
+		return &Circle{}
+	}, false)
+	assert.Error(t, err)
+
+	built.Reset()
+	assert.Len(t, built.Bindings(), 1)
+
+	err = c.Singleton(func() Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+	assert.Len(t, built.Bindings(), 1)
+}
+
+func TestContainer_Build_With_Factory(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.Factory(1, func(a int, s Shape) *Circle {
+		return &Circle{a: a + s.GetArea()}
+	})
+	assert.NoError(t, err)
+
+	built, err := c.Build()
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.InstanceAs[Shape](c.Override(), &Circle{a: 2}))
+
+	var factory func(a int) (*Circle, error)
+	assert.NoError(t, built.Resolve(&factory))
+
+	circle, err := factory(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, circle.GetArea())
+}
+
+func TestContainer_Build_With_Lazy_Singleton_Argument(t *testing.T) {
+	c := container.New()
+
+	err := c.SingletonLazy(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.Transient(func(s Shape) *Service {
+		return &Service{s: s}
+	})
+	assert.NoError(t, err)
+
+	built, err := c.Build()
+	assert.NoError(t, err)
+
+	var s1, s2 *Service
+	assert.NoError(t, built.Resolve(&s1))
+	assert.NoError(t, built.Resolve(&s2))
+	assert.NotSame(t, s1, s2)
+	assert.Same(t, s1.s, s2.s)
+}
+
+func TestContainer_Build_With_In_Argument(t *testing.T) {
+	c := container.New()
+
+	err := c.NamedSingleton("C", func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	type Parameters struct {
+		container.In
+
+		C Shape    `container:"name"`
+		D Database `container:"type,optional"`
+	}
+
+	err = c.TransientLazy(func(p Parameters) *Service {
+		return &Service{s: p.C, d: p.D}
+	})
+	assert.NoError(t, err)
+
+	built, err := c.Build()
+	assert.NoError(t, err)
+
+	var s *Service
+	assert.NoError(t, built.Resolve(&s))
+	assert.Equal(t, 13, s.s.GetArea())
+	assert.Nil(t, s.d)
+}
+
+func TestContainer_Build_With_Missing_Dependency_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	err := c.TransientLazy(func(s Shape) *Service {
+		return &Service{s: s}
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Build()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container: no concrete found for: container_test.Shape (required by *container_test.Service bound at ")
+
+	type Parameters struct {
+		container.In
+
+		S Shape `container:"type"`
+	}
+
+	c = container.New()
+	err = c.TransientLazy(func(p Parameters) *Service {
+		return &Service{s: p.S}
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Build()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container: no concrete found for: S field of container_test.Parameters")
+}
+
+func TestContainer_Build_With_Circular_Dependency_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	err := c.SingletonLazy(func(d Database) Shape {
+		return &Circle{}
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func(s Shape) Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Build()
+	assert.EqualError(t, err, "container: circular dependency: container_test.Database -> container_test.Shape -> container_test.Database")
+}
+
+func benchmarkContainer(b *testing.B) container.Container {
+	c := container.New()
+
+	if err := c.Singleton(func() Shape { return &Circle{a: 13} }); err != nil {
+		b.Fatal(err)
+	}
+	if err := c.TransientLazy(func() Database { return &MySQL{} }); err != nil {
+		b.Fatal(err)
+	}
+	if err := c.TransientLazy(func(s Shape, d Database) *Service { return &Service{s: s, d: d} }); err != nil {
+		b.Fatal(err)
+	}
+
+	return c
+}
+
+func BenchmarkContainer_Resolve_Service(b *testing.B) {
+	c := benchmarkContainer(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var s *Service
+		if err := c.Resolve(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContainer_Build_Resolve_Service(b *testing.B) {
+	c, err := benchmarkContainer(b).Build()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var s *Service
+		if err := c.Resolve(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkSingletonsContainer(b *testing.B) container.Container {
+	c := container.New()
+
+	if err := c.Singleton(func() Shape { return &Circle{a: 13} }); err != nil {
+		b.Fatal(err)
+	}
+	if err := c.SingletonLazy(func() Database { return &MySQL{} }); err != nil {
+		b.Fatal(err)
+	}
+	if err := c.TransientLazy(func(s Shape, d Database) *Service { return &Service{s: s, d: d} }); err != nil {
+		b.Fatal(err)
+	}
+
+	return c
+}
+
+func BenchmarkContainer_Resolve_Service_With_Singletons(b *testing.B) {
+	c := benchmarkSingletonsContainer(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var s *Service
+		if err := c.Resolve(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContainer_Build_Resolve_Service_With_Singletons(b *testing.B) {
+	c, err := benchmarkSingletonsContainer(b).Build()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var s *Service
+		if err := c.Resolve(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package container
+
+import "reflect"
+
+// Snapshot is a saved state of the bindings of a Container (see Container.Snapshot).
+type Snapshot struct {
+	bindings map[reflect.Type]map[string]*binding // bindings are the copies of the saved bindings.
+}
+
+// cloneOptions holds the settings of the Clone method.
+type cloneOptions struct {
+	resetSingletons bool // resetSingletons is true if the clone makes its own singleton concretes.
+}
+
+// CloneOption configures the Clone method.
+type CloneOption func(*cloneOptions)
+
+// WithResetSingletons makes the clone drop the singleton concretes that are already made,
+// so it makes its own concretes when they are resolved (or started, see Start).
+// The instance bindings keep their values, since they have no resolvers to make them again.
+func WithResetSingletons() CloneOption {
+	return func(o *cloneOptions) {
+		o.resetSingletons = true
+	}
+}
+
+// Clone returns a Container with copies of the bindings of c, so binding in one of them does not change the other.
+// By default, the copies share the singleton concretes that are already made (see WithResetSingletons),
+// and the singletons made later are made separately.
+// The clone shares the options and the parent of c, but it is not built (see Build) and has no started components.
+func (c Container) Clone(opts ...CloneOption) Container {
+	o := &cloneOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	clone := New()
+	clone.bindings = copyBindings(c.bindings)
+	clone.options = c.options
+	clone.parent = c.parent
+
+	for _, named := range clone.bindings {
+		for _, b := range named {
+			b.plan = nil
+			if o.resetSingletons {
+				b.reset()
+			}
+		}
+	}
+
+	return clone
+}
+
+// reset drops the made concrete of the binding (and its source), unless it is an instance binding.
+func (b *binding) reset() {
+	if b.resolver == nil && b.source == nil {
+		return
+	}
+
+	b.concrete = nil
+	b.value = reflect.Value{}
+	b.isMade.Store(false)
+	b.isConverted.Store(false)
+
+	if b.source != nil {
+		b.source.reset()
+	}
+}
+
+// SaveBinding saves the binding of the abstraction with the given name, with its singleton concrete if it is made.
+// It returns a function that brings the saved binding back, or deletes the binding if there was none to save.
+func (c Container) SaveBinding(abstraction reflect.Type, name string) (restore func() error) {
+	saved, exist := c.bindings[abstraction][name]
+	if exist {
+		saved = saved.clone()
+	}
+
+	return func() error {
+		if err := c.checkMutable(); err != nil {
+			return err
+		}
+
+		if !exist {
+			delete(c.bindings[abstraction], name)
+			if len(c.bindings[abstraction]) == 0 {
+				delete(c.bindings, abstraction)
+			}
+			return nil
+		}
+
+		if _, exist := c.bindings[abstraction]; !exist {
+			c.bindings[abstraction] = make(map[string]*binding)
+		}
+		c.bindings[abstraction][name] = saved.clone()
+
+		return nil
+	}
+}
+
+// Snapshot saves all the bindings of c and their singleton concretes that are already made (see Restore).
+func (c Container) Snapshot() Snapshot {
+	return Snapshot{bindings: copyBindings(c.bindings)}
+}
+
+// Restore replaces the bindings of c with the saved ones, including their singleton concretes.
+// The singletons made after the snapshot are dropped, and the snapshot can be restored more than once.
+func (c Container) Restore(snapshot Snapshot) error {
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	for k := range c.bindings {
+		delete(c.bindings, k)
+	}
+
+	for abstraction, named := range copyBindings(snapshot.bindings) {
+		c.bindings[abstraction] = named
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package container_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Clone(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func() *Circle {
+		return &Circle{a: 7}
+	})
+	assert.NoError(t, err)
+
+	clone := c.Clone()
+
+	err = clone.Override().Singleton(func() Shape {
+		return &Circle{a: 42}
+	})
+	assert.NoError(t, err)
+
+	var s Shape
+	assert.NoError(t, c.Resolve(&s))
+	assert.Equal(t, 13, s.GetArea())
+	assert.NoError(t, clone.Resolve(&s))
+	assert.Equal(t, 42, s.GetArea())
+
+	var c1, c2 *Circle
+	assert.NoError(t, clone.Resolve(&c1))
+	assert.NoError(t, c.Resolve(&c2))
+	assert.NotSame(t, c1, c2)
+}
+
+func TestContainer_Clone_Shares_Made_Singletons(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() *Circle {
+		return &Circle{a: 7}
+	})
+	assert.NoError(t, err)
+
+	var c1, c2 *Circle
+	assert.NoError(t, c.Resolve(&c1))
+	assert.NoError(t, c.Clone().Resolve(&c2))
+	assert.Same(t, c1, c2)
+}
+
+func TestContainer_Clone_With_Built_Container(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	built, err := c.Build()
+	assert.NoError(t, err)
+
+	clone := built.Clone()
+	err = clone.Override().Singleton(func() Shape {
+		return &Circle{a: 42}
+	})
+	assert.NoError(t, err)
+
+	err = clone.Transient(func(s Shape) Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	var s Shape
+	assert.NoError(t, clone.Resolve(&s))
+	assert.Equal(t, 42, s.GetArea())
+}
+
+func TestContainer_SaveBinding(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	var s1 Shape
+	assert.NoError(t, c.Resolve(&s1))
+
+	restoreShape := c.SaveBinding(shapeType, "")
+	restoreDatabase := c.SaveBinding(databaseType, "")
+
+	err = c.Override().Singleton(func() Shape {
+		return &Circle{a: 42}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, restoreShape())
+		assert.NoError(t, restoreDatabase())
+		assert.False(t, c.Has(databaseType, ""))
+
+		var s2 Shape
+		assert.NoError(t, c.Resolve(&s2))
+		assert.Same(t, s1, s2)
+	}
+}
+
+func TestContainer_SaveBinding_With_Built_Container(t *testing.T) {
+	c, err := container.New().Build()
+	assert.NoError(t, err)
+
+	assert.Error(t, c.SaveBinding(shapeType, "")())
+}
+
+func TestContainer_Snapshot_And_Restore(t *testing.T) {
+	c := container.New()
+
+	err := c.SingletonLazy(func() *Circle {
+		return &Circle{a: 7}
+	})
+	assert.NoError(t, err)
+
+	snapshot := c.Snapshot()
+
+	var c1 *Circle
+	assert.NoError(t, c.Resolve(&c1))
+
+	err = c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, c.Restore(snapshot))
+		assert.False(t, c.Has(shapeType, ""))
+
+		var c2 *Circle
+		assert.NoError(t, c.Resolve(&c2))
+		assert.NotSame(t, c1, c2)
+	}
+}
+
+func TestContainer_Restore_With_Built_Container(t *testing.T) {
+	c, err := container.New().Build()
+	assert.NoError(t, err)
+
+	assert.Error(t, c.Restore(c.Snapshot()))
+}
+
+func TestContainer_Clone_With_Reset_Singletons(t *testing.T) {
+	c := container.New()
+
+	calls := 0
+	err := c.Singleton(func() Outputs {
+		calls++
+		return Outputs{S: &Circle{a: 5}, C: &Circle{a: 13}, D: &MySQL{}}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() *Circle {
+		return &Circle{a: 7}
+	})
+	assert.NoError(t, err)
+
+	instance := &Circle{a: 42}
+	assert.NoError(t, container.NamedInstanceAs[Shape](c, "instance", instance))
+
+	clone := c.Clone(container.WithResetSingletons())
+	assert.Empty(t, clone.Bindings(container.ResolvedBindings, container.BindingsOf(reflect.TypeOf((*Database)(nil)).Elem())))
+
+	var c1, c2 *Circle
+	assert.NoError(t, c.Resolve(&c1))
+	assert.NoError(t, clone.Resolve(&c2))
+	assert.NotSame(t, c1, c2)
+
+	var s1, s2 Shape
+	assert.NoError(t, c.Resolve(&s1))
+	assert.NoError(t, clone.Resolve(&s2))
+	assert.NotSame(t, s1, s2)
+	assert.Equal(t, 5, s2.GetArea())
+
+	var named Shape
+	assert.NoError(t, clone.NamedResolve(&named, "C"))
+	assert.Equal(t, 13, named.GetArea())
+	assert.Equal(t, 2, calls)
+
+	var i Shape
+	assert.NoError(t, clone.NamedResolve(&i, "instance"))
+	assert.Same(t, instance, i)
+}
+
+func TestContainer_Clone_With_Factory(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.Factory(1, func(a int, s Shape) *Circle {
+		return &Circle{a: a + s.GetArea()}
+	})
+	assert.NoError(t, err)
+
+	var factory func(a int) (*Circle, error)
+	assert.NoError(t, c.Resolve(&factory))
+
+	for _, clone := range []container.Container{c.Clone(), c.Clone(container.WithResetSingletons())} {
+		assert.NoError(t, container.InstanceAs[Shape](clone.Override(), &Circle{a: 2}))
+
+		var cloneFactory func(a int) (*Circle, error)
+		assert.NoError(t, clone.Resolve(&cloneFactory))
+
+		circle, err := cloneFactory(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, circle.GetArea())
+	}
+
+	circle, err := factory(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, circle.GetArea())
+}
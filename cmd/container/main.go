@@ -0,0 +1,37 @@
+// Command container prints the bindings and the dependency graph of a container registration function.
+//
+// It generates a temporary main package that binds the dependencies with the registration function
+// of the package in the directory, like "func Register(c container.Container) error",
+// and runs it with the containerinspect commands. Examples:
+//
+//	container bindings
+//	container why *http.Server
+//	container why -name redis example.Cache
+//	container -dir ./app -func Wire check
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+func main() {
+	function := flag.String("func", "Register", "name of the registration function")
+	dir := flag.String("dir", ".", "package directory")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: container [flags] [bindings | why [-name N] TYPE | check]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*dir, *function, flag.Args(), os.Stdout, os.Stderr); err != nil {
+		var exit *exec.ExitError
+		if !errors.As(err, &exit) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// source is the template of the generated main package.
+var source = template.Must(template.New("main").Parse(`// Code generated by container. DO NOT EDIT.
+
+package main
+
+import (
+	"github.com/golobby/container/v4/containerinspect"
+
+	target {{printf "%q" .Path}}
+)
+
+func main() {
+	containerinspect.Main(target.{{.Function}})
+}
+`))
+
+// generate returns the source of the main package that runs the registration function of the package.
+func generate(path string, function string) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := source.Execute(&buffer, struct{ Path, Function string }{path, function}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buffer.Bytes())
+}
+
+// run generates the main package in a temporary directory inside the package directory, so it can import
+// internal packages too, and runs it with the arguments. The directory starts with an underscore
+// to be ignored by the go tool patterns, and it is removed after the run.
+func run(dir string, function string, args []string, stdout io.Writer, stderr io.Writer) error {
+	list := exec.Command("go", "list", "-f", "{{.ImportPath}}", ".")
+	list.Dir = dir
+	list.Stderr = stderr
+	output, err := list.Output()
+	if err != nil {
+		return fmt.Errorf("container: cannot load the package: %w", err)
+	}
+
+	code, err := generate(strings.TrimSpace(string(output)), function)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp(dir, "_container")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err = os.WriteFile(filepath.Join(tmp, "main.go"), code, 0o644); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", append([]string{"run", "./" + filepath.Base(tmp)}, args...)...)
+	cmd.Dir = dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	return cmd.Run()
+}
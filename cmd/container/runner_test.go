@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const example = "../containergen/internal/example"
+
+func TestGenerate(t *testing.T) {
+	code, err := generate("github.com/example/app", "Register")
+	assert.NoError(t, err)
+	assert.Equal(t, `// Code generated by container. DO NOT EDIT.
+
+package main
+
+import (
+	"github.com/golobby/container/v4/containerinspect"
+
+	target "github.com/example/app"
+)
+
+func main() {
+	containerinspect.Main(target.Register)
+}
+`, string(code))
+}
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run(example, "Register", []string{"why", "*example.Service"}, &stdout, &stderr)
+	assert.NoError(t, err, stderr.String())
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "*example.Service (transient, lazy, bound at "))
+	assert.True(t, strings.HasPrefix(lines[1], "├── example.Database (singleton, bound at "))
+	assert.True(t, strings.HasPrefix(lines[2], "│   └── *example.Config (singleton, bound at "))
+	assert.True(t, strings.HasPrefix(lines[3], "└── example.Cache (transient, lazy, bound at "))
+
+	entries, err := os.ReadDir(example)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		assert.False(t, strings.HasPrefix(entry.Name(), "_container"), "the temporary directory is not removed")
+	}
+}
+
+func TestRun_With_Failed_Command_It_Should_Fail(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run(example, "Register", []string{"why", "*http.Server"}, &stdout, &stderr)
+
+	var exit *exec.ExitError
+	assert.ErrorAs(t, err, &exit)
+	assert.Contains(t, stderr.String(), "containerinspect: no binding found for: *http.Server")
+}
+
+func TestRun_With_Missing_Function_It_Should_Fail(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run(example, "Wire", nil, &stdout, &stderr)
+	assert.Error(t, err)
+	assert.Contains(t, stderr.String(), "undefined: target.Wire")
+}
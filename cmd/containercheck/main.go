@@ -0,0 +1,15 @@
+// Command containercheck reports misuses of the container package.
+// It runs standalone on the given packages, or as a go vet tool:
+//
+//	containercheck ./...
+//	go vet -vettool=$(which containercheck) ./...
+package main
+
+import (
+	"github.com/golobby/container/containercheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(containercheck.Analyzer)
+}
@@ -0,0 +1,639 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// containerPath is the import path of the container package.
+const containerPath = "github.com/golobby/container/v4"
+
+// methods maps the supported binding methods to their lifetimes and laziness.
+var methods = map[string]struct{ named, singleton, lazy bool }{
+	"Singleton":          {false, true, false},
+	"SingletonLazy":      {false, true, true},
+	"NamedSingleton":     {true, true, false},
+	"NamedSingletonLazy": {true, true, true},
+	"Transient":          {false, false, false},
+	"TransientLazy":      {false, false, true},
+	"NamedTransient":     {true, false, false},
+	"NamedTransientLazy": {true, false, true},
+}
+
+// passive are the container functions and methods that can be called in registration functions without binding.
+var passive = map[string]bool{"New": true, "Override": true}
+
+// binding is a binding call found in the registration function.
+type binding struct {
+	index       int            // index is the position of the binding call in the registration function.
+	method      string         // method is the binding method, e.g. "NamedSingleton".
+	name        string         // name is the binding name, empty for typed bindings.
+	singleton   bool           // singleton is true for singleton bindings.
+	lazy        bool           // lazy is true for lazy bindings.
+	resolver    ast.Expr       // resolver is the resolver function expression.
+	abstraction types.Type     // abstraction is the type that the resolver returns.
+	parameters  []types.Type   // parameters are the resolver argument types.
+	hasError    bool           // hasError is true if the resolver returns an error as well.
+	position    token.Position // position is where the binding call is.
+	arguments   []*binding     // arguments are the bindings of the resolver arguments.
+}
+
+// label returns the abstraction and name of the binding for messages.
+func (b *binding) label() string {
+	if b.name == "" {
+		return display(b.abstraction)
+	}
+	return fmt.Sprintf("%s (name: %q)", display(b.abstraction), b.name)
+}
+
+// display returns the type qualified with package names, like the container messages (reflect.Type.String).
+func display(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string { return pkg.Name() })
+}
+
+// key identifies the binding of an abstraction with a name.
+type key struct {
+	abstraction string
+	name        string
+}
+
+// generator generates the static wiring of a registration function.
+type generator struct {
+	pkg      *packages.Package
+	function *ast.FuncDecl
+	bindings []*binding
+	final    map[key]*binding  // final are the bindings that remain after the duplicates replace the previous ones.
+	imports  map[string]string // imports maps the import names to paths in the generated file.
+	typeName string
+	errs     []string
+}
+
+// load loads and type-checks the package in the directory, ignoring the content of the output file.
+func load(dir, output string) (*packages.Package, error) {
+	config := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:     dir,
+		Overlay: map[string][]byte{},
+	}
+
+	if file, err := parser.ParseFile(token.NewFileSet(), output, nil, parser.PackageClauseOnly); err == nil {
+		path, err := filepath.Abs(output)
+		if err != nil {
+			return nil, err
+		}
+		config.Overlay[path] = []byte("package " + file.Name.Name + "\n")
+	}
+
+	pkgs, err := packages.Load(config, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("containergen: expected one package in %s, found %d", dir, len(pkgs))
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return nil, fmt.Errorf("containergen: %v", pkgs[0].Errors[0])
+	}
+
+	return pkgs[0], nil
+}
+
+// generate returns the generated source code of the wiring type for the registration function.
+func generate(pkg *packages.Package, function, typeName string) ([]byte, error) {
+	g := &generator{pkg: pkg, final: map[key]*binding{}, imports: map[string]string{}, typeName: typeName}
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Recv == nil && fd.Name.Name == function {
+				g.function = fd
+			}
+		}
+	}
+	if g.function == nil {
+		return nil, fmt.Errorf("containergen: function %s not found in package %s", function, pkg.PkgPath)
+	}
+
+	g.collect()
+	g.link()
+	g.detectCycles()
+	if len(g.errs) > 0 {
+		return nil, errors.New(strings.Join(g.errs, "\n"))
+	}
+
+	return g.render()
+}
+
+// errorf records an error at the given position.
+func (g *generator) errorf(pos token.Pos, format string, args ...interface{}) {
+	g.errs = append(g.errs, fmt.Sprintf("%s: %s", g.pkg.Fset.Position(pos), fmt.Sprintf(format, args...)))
+}
+
+// containerCall returns the name of the container function or method that the call expression calls, if any.
+// It also returns true if the call is a Must helper that takes the container as the first argument.
+func (g *generator) containerCall(call *ast.CallExpr) (string, bool) {
+	var ident *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.Ident:
+		ident = fun
+	default:
+		return "", false
+	}
+
+	f, ok := g.pkg.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || f.Pkg() == nil || f.Pkg().Path() != containerPath {
+		return "", false
+	}
+
+	if strings.HasPrefix(f.Name(), "Must") && f.Type().(*types.Signature).Recv() == nil {
+		return strings.TrimPrefix(f.Name(), "Must"), true
+	}
+
+	return f.Name(), false
+}
+
+// collect finds the binding calls in the registration function.
+// The bindings must always be made, so the calls must be in the top-level statements of the function,
+// or in the init statements and the conditions of its top-level if statements, like `if err := c.Singleton(...); err != nil`.
+func (g *generator) collect() {
+	for _, stmt := range g.function.Body.List {
+		if s, ok := stmt.(*ast.IfStmt); ok {
+			g.inspect(s.Init, true)
+			g.inspect(s.Cond, true)
+			g.inspect(s.Body, false)
+			g.inspect(s.Else, false)
+			continue
+		}
+		g.inspect(stmt, true)
+	}
+}
+
+// inspect finds the binding calls in the node, which always runs if it is unconditional.
+func (g *generator) inspect(node ast.Node, unconditional bool) {
+	if node == nil {
+		return
+	}
+
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch node.(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause, *ast.ForStmt, *ast.RangeStmt, *ast.FuncLit:
+			if unconditional {
+				g.inspect(node, false)
+				return false
+			}
+		}
+
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		method, isMust := g.containerCall(call)
+		if method == "" {
+			return true
+		}
+
+		kind, supported := methods[method]
+		if !supported {
+			if !passive[method] {
+				g.errorf(call.Pos(), "containergen: %s is not supported", method)
+			}
+			return true
+		}
+
+		if !unconditional {
+			g.errorf(call.Pos(), "containergen: %s in branches, loops and function literals is not supported", method)
+			return false
+		}
+
+		args := call.Args
+		if isMust {
+			args = args[1:]
+		}
+
+		b := &binding{
+			index:     len(g.bindings),
+			method:    method,
+			singleton: kind.singleton,
+			lazy:      kind.lazy,
+			resolver:  args[len(args)-1],
+			position:  g.pkg.Fset.Position(call.Pos()),
+		}
+
+		if kind.named {
+			value := g.pkg.TypesInfo.Types[args[0]].Value
+			if value == nil {
+				g.errorf(args[0].Pos(), "containergen: the binding name must be a constant string")
+				return false
+			}
+			b.name, _ = strconv.Unquote(value.ExactString())
+		}
+
+		if g.inspectResolver(b) {
+			g.bindings = append(g.bindings, b)
+			g.final[key{types.TypeString(b.abstraction, nil), b.name}] = b
+		}
+
+		return false
+	})
+}
+
+// inspectResolver validates the resolver of the binding and records its signature.
+func (g *generator) inspectResolver(b *binding) bool {
+	signature, ok := g.pkg.TypesInfo.TypeOf(b.resolver).(*types.Signature)
+	if !ok {
+		g.errorf(b.resolver.Pos(), "containergen: the resolver must be a function")
+		return false
+	}
+
+	if signature.Variadic() {
+		g.errorf(b.resolver.Pos(), "containergen: variadic resolvers are not supported")
+		return false
+	}
+
+	results := signature.Results()
+	switch {
+	case results.Len() == 1:
+	case results.Len() == 2 && types.Identical(results.At(1).Type(), types.Universe.Lookup("error").Type()):
+		b.hasError = true
+	default:
+		g.errorf(b.resolver.Pos(), "containergen: the resolver must return abstract, or abstract and error")
+		return false
+	}
+
+	b.abstraction = results.At(0).Type()
+	if isMarked(b.abstraction, "Out") {
+		g.errorf(b.resolver.Pos(), "containergen: Out structures are not supported")
+		return false
+	}
+
+	for i := 0; i < signature.Params().Len(); i++ {
+		parameter := signature.Params().At(i).Type()
+		if isMarked(parameter, "In") {
+			g.errorf(b.resolver.Pos(), "containergen: In structures are not supported")
+			return false
+		}
+		if types.Identical(parameter, b.abstraction) {
+			g.errorf(b.resolver.Pos(), "container: resolver function signature is invalid - depends on abstract it returns")
+			return false
+		}
+		b.parameters = append(b.parameters, parameter)
+	}
+
+	return g.inspectReferences(b.resolver)
+}
+
+// isMarked checks if the type is a structure that embeds the container marker type (In or Out).
+func isMarked(t types.Type, marker string) bool {
+	s, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < s.NumFields(); i++ {
+		named, ok := s.Field(i).Type().(*types.Named)
+		if s.Field(i).Embedded() && ok && named.Obj().Pkg() != nil &&
+			named.Obj().Pkg().Path() == containerPath && named.Obj().Name() == marker {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inspectReferences checks that the resolver does not capture local identifiers of the registration function,
+// and records the imports it refers to.
+func (g *generator) inspectReferences(resolver ast.Expr) bool {
+	valid := true
+
+	ast.Inspect(resolver, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		obj := g.pkg.TypesInfo.Uses[ident]
+		if obj == nil {
+			return true
+		}
+
+		if pkgName, ok := obj.(*types.PkgName); ok {
+			if path, exist := g.imports[ident.Name]; exist && path != pkgName.Imported().Path() {
+				g.errorf(ident.Pos(), "containergen: import name %s refers to both %s and %s", ident.Name, path, pkgName.Imported().Path())
+				valid = false
+			}
+			g.imports[ident.Name] = pkgName.Imported().Path()
+			return true
+		}
+
+		if obj.Parent() == g.pkg.Types.Scope() && shadowed(ident.Name) {
+			g.errorf(ident.Pos(), "containergen: the resolver refers to %s, which the generated code shadows", ident.Name)
+			valid = false
+		}
+
+		inFunction := obj.Pos() >= g.function.Pos() && obj.Pos() < g.function.End()
+		inResolver := obj.Pos() >= resolver.Pos() && obj.Pos() < resolver.End()
+		if inFunction && !inResolver {
+			g.errorf(ident.Pos(), "containergen: the resolver captures %s declared in %s", ident.Name, g.function.Name.Name)
+			valid = false
+		}
+
+		return true
+	})
+
+	return valid
+}
+
+// shadowed checks if the identifier is declared in the generated methods that call the resolvers.
+func shadowed(name string) bool {
+	if name == "w" || name == "concrete" || name == "err" {
+		return true
+	}
+	if strings.HasPrefix(name, "a") {
+		_, err := strconv.Atoi(name[1:])
+		return err == nil
+	}
+	return false
+}
+
+// link finds the bindings of the resolver arguments.
+// The arguments of non-lazy bindings must be bound before them, since they are resolved at the binding time.
+func (g *generator) link() {
+	for _, b := range g.bindings {
+		for _, parameter := range b.parameters {
+			dependency, exist := g.final[key{types.TypeString(parameter, nil), ""}]
+			if !exist {
+				g.errs = append(g.errs, fmt.Sprintf(
+					"%s: container: no concrete found for: %s", b.position, display(parameter),
+				))
+				continue
+			}
+
+			if !b.lazy && !g.boundBefore(parameter, b.index) {
+				g.errs = append(g.errs, fmt.Sprintf(
+					"%s: container: no concrete found for: %s (it is bound after the non-lazy %s)",
+					b.position, display(parameter), b.label(),
+				))
+				continue
+			}
+
+			b.arguments = append(b.arguments, dependency)
+		}
+	}
+}
+
+// boundBefore checks if the abstraction is bound (without a name) before the binding with the given index.
+func (g *generator) boundBefore(abstraction types.Type, index int) bool {
+	for _, b := range g.bindings[:index] {
+		if b.name == "" && types.Identical(b.abstraction, abstraction) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectCycles reports the circular dependencies between the bindings.
+func (g *generator) detectCycles() {
+	states := map[*binding]int{}
+	var path []string
+
+	var visit func(b *binding) bool
+	visit = func(b *binding) bool {
+		switch states[b] {
+		case 1:
+			g.errs = append(g.errs, fmt.Sprintf(
+				"%s: container: circular dependency: %s -> %s", b.position, strings.Join(path, " -> "), b.label(),
+			))
+			return false
+		case 2:
+			return true
+		}
+
+		states[b] = 1
+		path = append(path, b.label())
+		defer func() {
+			path = path[:len(path)-1]
+			states[b] = 2
+		}()
+
+		for _, argument := range b.arguments {
+			if !visit(argument) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for _, b := range g.bindings {
+		if !visit(b) {
+			return
+		}
+	}
+}
+
+// qualifier returns the import name of the package in the generated file and records the import.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg.Path() == g.pkg.PkgPath {
+		return ""
+	}
+
+	for name, path := range g.imports {
+		if path == pkg.Path() {
+			return name
+		}
+	}
+
+	name := pkg.Name()
+	for i := 2; g.imports[name] != ""; i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+	g.imports[name] = pkg.Path()
+
+	return name
+}
+
+// typeString returns the type as written in the generated file.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+// source returns the source code of the node.
+func (g *generator) source(node ast.Node) string {
+	var buffer bytes.Buffer
+	if err := format.Node(&buffer, g.pkg.Fset, node); err != nil {
+		panic(err)
+	}
+	return buffer.String()
+}
+
+// render writes the generated file.
+func (g *generator) render() ([]byte, error) {
+	var body bytes.Buffer
+	w := func(format string, args ...interface{}) {
+		fmt.Fprintf(&body, format, args...)
+	}
+
+	w("// %s holds the bindings of %s and makes their concretes without reflection.\n", g.typeName, g.function.Name.Name)
+	w("type %s struct {\n", g.typeName)
+	for _, b := range g.bindings {
+		if b.singleton {
+			w("concrete%d %s // concrete%d is the %s concrete bound at %s.\n", b.index, g.typeString(b.abstraction), b.index, b.label(), relative(b.position))
+			w("made%d bool\n", b.index)
+			if b.lazy {
+				w("mu%d sync.Mutex // mu%d guards the lazy concrete%d, which is made on the first resolve.\n", b.index, b.index, b.index)
+			}
+		}
+	}
+	w("}\n\n")
+
+	w("// New%s makes the non-lazy bindings of %s in order and returns the %s.\n", g.typeName, g.function.Name.Name, g.typeName)
+	w("func New%s() (*%s, error) {\n", g.typeName, g.typeName)
+	w("w := &%s{}\n", g.typeName)
+	for _, b := range g.bindings {
+		if !b.lazy {
+			w("if _, err := w.make%d(); err != nil {\nreturn nil, err\n}\n", b.index)
+		}
+	}
+	w("return w, nil\n}\n\n")
+
+	for _, b := range g.bindings {
+		lifetime := "transient"
+		if b.singleton {
+			lifetime = "singleton"
+		}
+
+		w("// make%d makes the %s concrete of %s bound at %s.\n", b.index, lifetime, b.label(), relative(b.position))
+		w("func (w *%s) make%d() (concrete %s, err error) {\n", g.typeName, b.index, g.typeString(b.abstraction))
+		if b.singleton && b.lazy {
+			w("w.mu%d.Lock()\ndefer w.mu%d.Unlock()\n", b.index, b.index)
+		}
+		if b.singleton {
+			w("if w.made%d {\nreturn w.concrete%d, nil\n}\n", b.index, b.index)
+		}
+
+		arguments := make([]string, len(b.arguments))
+		for i, argument := range b.arguments {
+			arguments[i] = fmt.Sprintf("a%d", i)
+			w("a%d, err := w.make%d()\nif err != nil {\nreturn concrete, err\n}\n", i, argument.index)
+		}
+
+		resolver := g.source(b.resolver)
+		if _, ok := b.resolver.(*ast.FuncLit); ok {
+			resolver = "(" + resolver + ")"
+		}
+
+		if b.hasError {
+			w("if concrete, err = %s(%s); err != nil {\nreturn concrete, err\n}\n", resolver, strings.Join(arguments, ", "))
+		} else {
+			w("concrete = %s(%s)\n", resolver, strings.Join(arguments, ", "))
+		}
+
+		if b.singleton {
+			w("w.concrete%d, w.made%d = concrete, true\n", b.index, b.index)
+		}
+		w("return concrete, nil\n}\n\n")
+	}
+
+	w("// Resolve takes an abstraction (reference of an interface type) and fills it with the related concrete.\n")
+	w("func (w *%s) Resolve(abstraction interface{}) error {\nreturn w.NamedResolve(abstraction, \"\")\n}\n\n", g.typeName)
+
+	names := map[string][]*binding{}
+	for _, b := range g.final {
+		names[b.name] = append(names[b.name], b)
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	w("// NamedResolve takes abstraction and its name and fills it with the related concrete.\n")
+	w("func (w *%s) NamedResolve(abstraction interface{}, name string) error {\n", g.typeName)
+	w("switch name {\n")
+	for _, name := range sortedNames {
+		bindings := names[name]
+		sort.Slice(bindings, func(i, j int) bool { return bindings[i].index < bindings[j].index })
+
+		w("case %q:\nswitch a := abstraction.(type) {\n", name)
+		for _, b := range bindings {
+			w("case *%s:\n", g.typeString(b.abstraction))
+			w("concrete, err := w.make%d()\nif err != nil {\n", b.index)
+			w("return fmt.Errorf(\"container: encountered error while making concrete for: %s. Error encountered: %%w\", err)\n}\n", display(b.abstraction))
+			w("*a = concrete\nreturn nil\n")
+		}
+		w("}\n")
+	}
+	w("}\n\n")
+	w("return fmt.Errorf(\"container: no concrete found for: %%s\", strings.TrimPrefix(fmt.Sprintf(\"%%T\", abstraction), \"*\"))\n}\n")
+
+	g.imports["fmt"] = "fmt"
+	g.imports["strings"] = "strings"
+	for _, b := range g.bindings {
+		if b.singleton && b.lazy {
+			g.imports["sync"] = "sync"
+		}
+	}
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by containergen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg.Name)
+
+	importNames := make([]string, 0, len(g.imports))
+	for name := range g.imports {
+		importNames = append(importNames, name)
+	}
+	sort.Slice(importNames, func(i, j int) bool { return g.imports[importNames[i]] < g.imports[importNames[j]] })
+
+	file.WriteString("import (\n")
+	for _, name := range importNames {
+		path := g.imports[name]
+		if filepath.Base(path) == name {
+			fmt.Fprintf(&file, "%q\n", path)
+		} else {
+			fmt.Fprintf(&file, "%s %q\n", name, path)
+		}
+	}
+	file.WriteString(")\n\n")
+	file.Write(body.Bytes())
+
+	return format.Source(file.Bytes())
+}
+
+// relative returns the position with the base name of the file, to keep the generated code stable across machines.
+func relative(position token.Position) string {
+	return fmt.Sprintf("%s:%d", filepath.Base(position.Filename), position.Line)
+}
+
+// write generates the wiring and writes it to the output file.
+func write(dir, function, typeName, output string) error {
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(dir, output)
+	}
+
+	pkg, err := load(dir, output)
+	if err != nil {
+		return err
+	}
+
+	content, err := generate(pkg, function, typeName)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, content, 0o644)
+}
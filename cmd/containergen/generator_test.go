@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate_It_Should_Match_The_Example(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	output := filepath.Join(dir, "container_gen.go")
+
+	pkg, err := load(dir, output)
+	assert.NoError(t, err)
+
+	content, err := generate(pkg, "Register", "Wiring")
+	assert.NoError(t, err)
+
+	expected, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(content), "run go generate in %s", dir)
+}
+
+func TestGenerate_With_Errors(t *testing.T) {
+	cases := map[string]string{
+		"cycle":       "container: circular dependency: *cycle.A -> *cycle.B -> *cycle.A",
+		"missing":     "container: no concrete found for: *missing.B (it is bound after the non-lazy *missing.A)",
+		"capture":     "containergen: the resolver captures name declared in Register",
+		"unsupported": "containergen: Instance is not supported",
+		"conditional": "containergen: Singleton in branches, loops and function literals is not supported",
+	}
+
+	for name, message := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join("testdata", name)
+
+			pkg, err := load(dir, filepath.Join(dir, "container_gen.go"))
+			assert.NoError(t, err)
+
+			_, err = generate(pkg, "Register", "Wiring")
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), message)
+		})
+	}
+}
+
+func TestGenerate_With_Unknown_Function_It_Should_Fail(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+
+	pkg, err := load(dir, filepath.Join(dir, "container_gen.go"))
+	assert.NoError(t, err)
+
+	_, err = generate(pkg, "Unknown", "Wiring")
+	assert.EqualError(t, err, "containergen: function Unknown not found in package github.com/golobby/container/cmd/containergen/internal/example")
+}
@@ -0,0 +1,150 @@
+// Code generated by containergen. DO NOT EDIT.
+
+package example
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Wiring holds the bindings of Register and makes their concretes without reflection.
+type Wiring struct {
+	concrete0 *Config // concrete0 is the *example.Config concrete bound at example.go:69.
+	made0     bool
+	concrete1 Database // concrete1 is the example.Database concrete bound at example.go:75.
+	made1     bool
+	concrete2 Cache // concrete2 is the example.Cache (name: "remote") concrete bound at example.go:79.
+	made2     bool
+	mu2       sync.Mutex // mu2 guards the lazy concrete2, which is made on the first resolve.
+}
+
+// NewWiring makes the non-lazy bindings of Register in order and returns the Wiring.
+func NewWiring() (*Wiring, error) {
+	w := &Wiring{}
+	if _, err := w.make0(); err != nil {
+		return nil, err
+	}
+	if _, err := w.make1(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// make0 makes the singleton concrete of *example.Config bound at example.go:69.
+func (w *Wiring) make0() (concrete *Config, err error) {
+	if w.made0 {
+		return w.concrete0, nil
+	}
+	concrete = (func() *Config {
+		return &Config{DSN: "MYSQL://LOCALHOST"}
+	})()
+	w.concrete0, w.made0 = concrete, true
+	return concrete, nil
+}
+
+// make1 makes the singleton concrete of example.Database bound at example.go:75.
+func (w *Wiring) make1() (concrete Database, err error) {
+	if w.made1 {
+		return w.concrete1, nil
+	}
+	a0, err := w.make0()
+	if err != nil {
+		return concrete, err
+	}
+	if concrete, err = NewDatabase(a0); err != nil {
+		return concrete, err
+	}
+	w.concrete1, w.made1 = concrete, true
+	return concrete, nil
+}
+
+// make2 makes the singleton concrete of example.Cache (name: "remote") bound at example.go:79.
+func (w *Wiring) make2() (concrete Cache, err error) {
+	w.mu2.Lock()
+	defer w.mu2.Unlock()
+	if w.made2 {
+		return w.concrete2, nil
+	}
+	concrete = (func() Cache {
+		return &Memory{name: "remote"}
+	})()
+	w.concrete2, w.made2 = concrete, true
+	return concrete, nil
+}
+
+// make3 makes the transient concrete of example.Cache bound at example.go:83.
+func (w *Wiring) make3() (concrete Cache, err error) {
+	concrete = (func() Cache {
+		return &Memory{name: "local"}
+	})()
+	return concrete, nil
+}
+
+// make4 makes the transient concrete of *example.Service bound at example.go:89.
+func (w *Wiring) make4() (concrete *Service, err error) {
+	a0, err := w.make1()
+	if err != nil {
+		return concrete, err
+	}
+	a1, err := w.make3()
+	if err != nil {
+		return concrete, err
+	}
+	concrete = NewService(a0, a1)
+	return concrete, nil
+}
+
+// Resolve takes an abstraction (reference of an interface type) and fills it with the related concrete.
+func (w *Wiring) Resolve(abstraction interface{}) error {
+	return w.NamedResolve(abstraction, "")
+}
+
+// NamedResolve takes abstraction and its name and fills it with the related concrete.
+func (w *Wiring) NamedResolve(abstraction interface{}, name string) error {
+	switch name {
+	case "":
+		switch a := abstraction.(type) {
+		case **Config:
+			concrete, err := w.make0()
+			if err != nil {
+				return fmt.Errorf("container: encountered error while making concrete for: *example.Config. Error encountered: %w", err)
+			}
+			*a = concrete
+			return nil
+		case *Database:
+			concrete, err := w.make1()
+			if err != nil {
+				return fmt.Errorf("container: encountered error while making concrete for: example.Database. Error encountered: %w", err)
+			}
+			*a = concrete
+			return nil
+		case *Cache:
+			concrete, err := w.make3()
+			if err != nil {
+				return fmt.Errorf("container: encountered error while making concrete for: example.Cache. Error encountered: %w", err)
+			}
+			*a = concrete
+			return nil
+		case **Service:
+			concrete, err := w.make4()
+			if err != nil {
+				return fmt.Errorf("container: encountered error while making concrete for: *example.Service. Error encountered: %w", err)
+			}
+			*a = concrete
+			return nil
+		}
+	case "remote":
+		switch a := abstraction.(type) {
+		case *Cache:
+			concrete, err := w.make2()
+			if err != nil {
+				return fmt.Errorf("container: encountered error while making concrete for: example.Cache. Error encountered: %w", err)
+			}
+			*a = concrete
+			return nil
+		}
+	}
+
+	return fmt.Errorf("container: no concrete found for: %s", strings.TrimPrefix(fmt.Sprintf("%T", abstraction), "*"))
+}
@@ -0,0 +1,90 @@
+// Package example is a sample application wired by the container and by the code that containergen generates.
+package example
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golobby/container/v4"
+)
+
+//go:generate go run github.com/golobby/container/cmd/containergen -func Register -type Wiring
+
+// Config is the application configuration.
+type Config struct {
+	DSN string
+}
+
+// Database is the database abstraction.
+type Database interface {
+	DSN() string
+}
+
+// MySQL is the MySQL implementation of Database.
+type MySQL struct {
+	dsn string
+}
+
+// DSN returns the data source name.
+func (m *MySQL) DSN() string {
+	return m.dsn
+}
+
+// Cache is the cache abstraction.
+type Cache interface {
+	Name() string
+}
+
+// Memory is an in-memory implementation of Cache.
+type Memory struct {
+	name string
+}
+
+// Name returns the cache name.
+func (m *Memory) Name() string {
+	return m.name
+}
+
+// Service depends on the database and the cache.
+type Service struct {
+	DB    Database
+	Cache Cache
+}
+
+// NewService creates a Service.
+func NewService(db Database, cache Cache) *Service {
+	return &Service{DB: db, Cache: cache}
+}
+
+// NewDatabase creates a Database from the configuration.
+func NewDatabase(config *Config) (Database, error) {
+	if config.DSN == "" {
+		return nil, errors.New("example: empty dsn")
+	}
+	return &MySQL{dsn: strings.ToLower(config.DSN)}, nil
+}
+
+// Register binds the application dependencies.
+func Register(c container.Container) error {
+	if err := c.Singleton(func() *Config {
+		return &Config{DSN: "MYSQL://LOCALHOST"}
+	}); err != nil {
+		return err
+	}
+
+	if err := c.Singleton(NewDatabase); err != nil {
+		return err
+	}
+
+	container.MustNamedSingletonLazy(c, "remote", func() Cache {
+		return &Memory{name: "remote"}
+	})
+
+	if err := c.TransientLazy(func() Cache {
+		return &Memory{name: "local"}
+	}); err != nil {
+		return err
+	}
+
+	return c.TransientLazy(NewService)
+}
@@ -0,0 +1,71 @@
+package example_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/golobby/container/cmd/containergen/internal/example"
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWiring_It_Should_Resolve_Like_The_Container(t *testing.T) {
+	c := container.New()
+	assert.NoError(t, example.Register(c))
+
+	w, err := example.NewWiring()
+	assert.NoError(t, err)
+
+	var fromContainer, fromWiring *example.Service
+	assert.NoError(t, c.Resolve(&fromContainer))
+	assert.NoError(t, w.Resolve(&fromWiring))
+	assert.Equal(t, fromContainer, fromWiring)
+	assert.Equal(t, "mysql://localhost", fromWiring.DB.DSN())
+	assert.Equal(t, "local", fromWiring.Cache.Name())
+
+	var another *example.Service
+	assert.NoError(t, w.Resolve(&another))
+	assert.NotSame(t, fromWiring, another)
+	assert.Same(t, fromWiring.DB, another.DB)
+	assert.NotSame(t, fromWiring.Cache, another.Cache)
+
+	var remote1, remote2 example.Cache
+	assert.NoError(t, w.NamedResolve(&remote1, "remote"))
+	assert.NoError(t, w.NamedResolve(&remote2, "remote"))
+	assert.Equal(t, "remote", remote1.Name())
+	assert.Same(t, remote1, remote2)
+}
+
+func TestWiring_With_Concurrent_Lazy_Singleton_Resolves(t *testing.T) {
+	w, err := example.NewWiring()
+	assert.NoError(t, err)
+
+	caches := make([]example.Cache, 8)
+
+	var wg sync.WaitGroup
+	for i := range caches {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, w.NamedResolve(&caches[i], "remote"))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, cache := range caches {
+		assert.Same(t, caches[0], cache)
+	}
+}
+
+func TestWiring_With_Unbound_Abstraction_It_Should_Fail(t *testing.T) {
+	w, err := example.NewWiring()
+	assert.NoError(t, err)
+
+	var s string
+	err = w.Resolve(&s)
+	assert.EqualError(t, err, "container: no concrete found for: string")
+
+	var cache example.Cache
+	err = w.NamedResolve(&cache, "unknown")
+	assert.EqualError(t, err, "container: no concrete found for: example.Cache")
+}
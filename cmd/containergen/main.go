@@ -0,0 +1,30 @@
+// Command containergen generates static wiring code from a container registration function.
+//
+// It reads a registration function that binds resolvers with the Singleton, Transient,
+// and Named methods (and their lazy, global and Must forms), type-checks it, and writes plain Go code
+// that makes the same graph without reflection. Missing bindings and circular dependencies
+// are reported at generate time. Example:
+//
+//	//go:generate go run github.com/golobby/container/cmd/containergen -func Register -type Wiring
+//
+// The generated type offers the Resolve and NamedResolve methods like the Container.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	function := flag.String("func", "Register", "name of the registration function")
+	typeName := flag.String("type", "Wiring", "name of the generated type")
+	output := flag.String("output", "container_gen.go", "output file name, relative to the package directory")
+	dir := flag.String("dir", ".", "package directory")
+	flag.Parse()
+
+	if err := write(*dir, *function, *typeName, *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,9 @@
+package capture
+
+import "github.com/golobby/container/v4"
+
+type A struct{ name string }
+
+func Register(c container.Container, name string) {
+	container.MustSingleton(c, func() *A { return &A{name: name} })
+}
@@ -0,0 +1,11 @@
+package conditional
+
+import "github.com/golobby/container/v4"
+
+type A struct{}
+
+func Register(c container.Container, remote bool) {
+	if remote {
+		container.MustSingleton(c, func() *A { return &A{} })
+	}
+}
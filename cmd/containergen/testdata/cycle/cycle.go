@@ -0,0 +1,11 @@
+package cycle
+
+import "github.com/golobby/container/v4"
+
+type A struct{}
+type B struct{}
+
+func Register(c container.Container) {
+	container.MustSingletonLazy(c, func(b *B) *A { return &A{} })
+	container.MustSingletonLazy(c, func(a *A) *B { return &B{} })
+}
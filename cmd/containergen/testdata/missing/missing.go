@@ -0,0 +1,11 @@
+package missing
+
+import "github.com/golobby/container/v4"
+
+type A struct{}
+type B struct{}
+
+func Register(c container.Container) {
+	container.MustSingleton(c, func(b *B) *A { return &A{} })
+	container.MustSingleton(c, func() *B { return &B{} })
+}
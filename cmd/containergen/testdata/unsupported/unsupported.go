@@ -0,0 +1,9 @@
+package unsupported
+
+import "github.com/golobby/container/v4"
+
+type A struct{}
+
+func Register(c container.Container) error {
+	return c.Instance(&A{})
+}
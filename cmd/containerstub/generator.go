@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// containertestPath is the import path of the package that the stubs are registered with.
+const containertestPath = "github.com/golobby/container/v4/containertest"
+
+// stub is an interface to generate a stub type for.
+type stub struct {
+	name     string           // name is the interface name.
+	iface    *types.Interface // iface is the interface type with its embedded methods.
+	typeName string           // typeName is the name of the stub type.
+}
+
+type generator struct {
+	pkg      *packages.Package
+	stubs    []stub
+	imports  map[string]string // imports maps the import names to paths in the generated file.
+	function string
+	errs     []string
+}
+
+// load loads and type-checks the package in the directory, ignoring the content of the output file.
+func load(dir, output string) (*packages.Package, error) {
+	config := &packages.Config{
+		Mode:    packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
+		Dir:     dir,
+		Overlay: map[string][]byte{},
+	}
+
+	if file, err := parser.ParseFile(token.NewFileSet(), output, nil, parser.PackageClauseOnly); err == nil {
+		path, err := filepath.Abs(output)
+		if err != nil {
+			return nil, err
+		}
+		config.Overlay[path] = []byte("package " + file.Name.Name + "\n")
+	}
+
+	pkgs, err := packages.Load(config, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("containerstub: expected one package in %s, found %d", dir, len(pkgs))
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return nil, fmt.Errorf("containerstub: %v", pkgs[0].Errors[0])
+	}
+
+	return pkgs[0], nil
+}
+
+// generate returns the generated source code of the stubs of the interfaces and their registration function.
+func generate(pkg *packages.Package, typeNames []string, function string) ([]byte, error) {
+	g := &generator{pkg: pkg, imports: map[string]string{"containertest": containertestPath}, function: function}
+
+	for _, name := range typeNames {
+		g.collect(strings.TrimSpace(name))
+	}
+	if len(g.errs) > 0 {
+		return nil, errors.New(strings.Join(g.errs, "\n"))
+	}
+
+	return g.render()
+}
+
+// collect checks that the named type is an interface that can be stubbed and adds it to the stubs.
+func (g *generator) collect(name string) {
+	object, ok := g.pkg.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		g.errs = append(g.errs, fmt.Sprintf("containerstub: type %s not found in package %s", name, g.pkg.PkgPath))
+		return
+	}
+
+	iface, ok := object.Type().Underlying().(*types.Interface)
+	if !ok {
+		g.errs = append(g.errs, fmt.Sprintf("containerstub: %s is not an interface", name))
+		return
+	}
+	if named, ok := object.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+		g.errs = append(g.errs, fmt.Sprintf("containerstub: %s is generic, so it cannot be stubbed", name))
+		return
+	}
+	if !iface.IsMethodSet() {
+		g.errs = append(g.errs, fmt.Sprintf("containerstub: %s is a constraint, so it cannot be stubbed", name))
+		return
+	}
+
+	for i := 0; i < iface.NumMethods(); i++ {
+		if method := iface.Method(i); !method.Exported() && method.Pkg() != g.pkg.Types {
+			g.errs = append(g.errs, fmt.Sprintf(
+				"containerstub: %s has the unexported method %s of package %s", name, method.Name(), method.Pkg().Path(),
+			))
+			return
+		}
+	}
+
+	typeName := name + "Stub"
+	if g.pkg.Types.Scope().Lookup(typeName) != nil {
+		g.errs = append(g.errs, fmt.Sprintf("containerstub: %s is already declared in package %s", typeName, g.pkg.PkgPath))
+		return
+	}
+
+	g.stubs = append(g.stubs, stub{name: name, iface: iface, typeName: typeName})
+}
+
+// qualifier returns the import name of the package in the generated file and records the import.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg.Path() == g.pkg.PkgPath {
+		return ""
+	}
+
+	for name, path := range g.imports {
+		if path == pkg.Path() {
+			return name
+		}
+	}
+
+	name := pkg.Name()
+	for i := 2; g.imports[name] != ""; i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+	g.imports[name] = pkg.Path()
+
+	return name
+}
+
+// typeString returns the type as written in the generated file.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+// method returns the stub method that records the call and returns zero values with its named results.
+func (g *generator) method(typeName string, method *types.Func) string {
+	signature := method.Type().(*types.Signature)
+
+	parameters := make([]string, signature.Params().Len())
+	arguments := make([]string, 0, signature.Params().Len()+1)
+	arguments = append(arguments, fmt.Sprintf("%q", method.Name()))
+	for i := range parameters {
+		t := g.typeString(signature.Params().At(i).Type())
+		if signature.Variadic() && i == len(parameters)-1 {
+			t = "..." + g.typeString(signature.Params().At(i).Type().(*types.Slice).Elem())
+		}
+		parameters[i] = fmt.Sprintf("p%d %s", i, t)
+		arguments = append(arguments, fmt.Sprintf("p%d", i))
+	}
+
+	results := make([]string, signature.Results().Len())
+	for i := range results {
+		results[i] = fmt.Sprintf("r%d %s", i, g.typeString(signature.Results().At(i).Type()))
+	}
+
+	if len(results) == 0 {
+		return fmt.Sprintf(
+			"func (s %s) %s(%s) {\ns.Recorder.Record(%s)\n}\n\n",
+			typeName, method.Name(), strings.Join(parameters, ", "), strings.Join(arguments, ", "),
+		)
+	}
+
+	return fmt.Sprintf(
+		"func (s %s) %s(%s) (%s) {\ns.Recorder.Record(%s)\nreturn\n}\n\n",
+		typeName, method.Name(), strings.Join(parameters, ", "), strings.Join(results, ", "), strings.Join(arguments, ", "),
+	)
+}
+
+// render writes the generated file.
+func (g *generator) render() ([]byte, error) {
+	var body bytes.Buffer
+	w := func(format string, args ...interface{}) {
+		fmt.Fprintf(&body, format, args...)
+	}
+
+	names := make([]string, len(g.stubs))
+	for i, s := range g.stubs {
+		names[i] = s.name
+
+		w("// %s is a stub of %s that records its calls and returns zero values.\n", s.typeName, s.name)
+		w("type %s struct{ *containertest.Recorder }\n\n", s.typeName)
+		for j := 0; j < s.iface.NumMethods(); j++ {
+			body.WriteString(g.method(s.typeName, s.iface.Method(j)))
+		}
+	}
+
+	list := names[len(names)-1]
+	if len(names) > 1 {
+		list = strings.Join(names[:len(names)-1], ", ") + " and " + list
+	}
+
+	w("// %s registers the stubs of %s (see containertest.RegisterStub).\n", g.function, list)
+	w("func %s(stubs *containertest.Stubs) {\n", g.function)
+	for _, s := range g.stubs {
+		w("containertest.RegisterStub(stubs, func(r *containertest.Recorder) %s {\nreturn %s{r}\n})\n", s.name, s.typeName)
+	}
+	w("}\n")
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by containerstub. DO NOT EDIT.\n\npackage %s\n\n", g.pkg.Name)
+
+	importNames := make([]string, 0, len(g.imports))
+	for name := range g.imports {
+		importNames = append(importNames, name)
+	}
+	sort.Slice(importNames, func(i, j int) bool { return g.imports[importNames[i]] < g.imports[importNames[j]] })
+
+	file.WriteString("import (\n")
+	for _, name := range importNames {
+		path := g.imports[name]
+		if filepath.Base(path) == name {
+			fmt.Fprintf(&file, "%q\n", path)
+		} else {
+			fmt.Fprintf(&file, "%s %q\n", name, path)
+		}
+	}
+	file.WriteString(")\n\n")
+	file.Write(body.Bytes())
+
+	return format.Source(file.Bytes())
+}
+
+// write generates the stubs and writes them to the output file.
+func write(dir string, typeNames []string, function, output string) error {
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(dir, output)
+	}
+
+	pkg, err := load(dir, output)
+	if err != nil {
+		return err
+	}
+
+	content, err := generate(pkg, typeNames, function)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, content, 0o644)
+}
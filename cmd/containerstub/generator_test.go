@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate_It_Should_Match_The_Example(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	output := filepath.Join(dir, "container_stubs_test.go")
+
+	pkg, err := load(dir, output)
+	assert.NoError(t, err)
+
+	content, err := generate(pkg, []string{"Mailer", "Clock"}, "RegisterStubs")
+	assert.NoError(t, err)
+
+	expected, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(content), "run go generate in %s", dir)
+}
+
+func TestGenerate_With_Errors(t *testing.T) {
+	cases := map[string]string{
+		"Unknown": "containerstub: type Unknown not found in package github.com/golobby/container/cmd/containerstub/testdata/invalid",
+		"Config":  "containerstub: Config is not an interface",
+		"Store":   "containerstub: Store is generic, so it cannot be stubbed",
+		"Number":  "containerstub: Number is a constraint, so it cannot be stubbed",
+		"Kind":    "containerstub: Kind has the unexported method common of package reflect",
+		"Logger":  "containerstub: LoggerStub is already declared in package github.com/golobby/container/cmd/containerstub/testdata/invalid",
+	}
+
+	dir := filepath.Join("testdata", "invalid")
+	pkg, err := load(dir, filepath.Join(dir, "container_stubs_test.go"))
+	assert.NoError(t, err)
+
+	for name, message := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := generate(pkg, []string{"Printer", name}, "RegisterStubs")
+			assert.EqualError(t, err, message)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Code generated by containerstub. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"github.com/golobby/container/v4/containertest"
+	"time"
+)
+
+// MailerStub is a stub of Mailer that records its calls and returns zero values.
+type MailerStub struct{ *containertest.Recorder }
+
+func (s MailerStub) Failed(p0 ...int) (r0 []string) {
+	s.Recorder.Record("Failed", p0)
+	return
+}
+
+func (s MailerStub) Send(p0 context.Context, p1 string, p2 string) (r0 error) {
+	s.Recorder.Record("Send", p0, p1, p2)
+	return
+}
+
+// ClockStub is a stub of Clock that records its calls and returns zero values.
+type ClockStub struct{ *containertest.Recorder }
+
+func (s ClockStub) Now() (r0 time.Time) {
+	s.Recorder.Record("Now")
+	return
+}
+
+func (s ClockStub) Sleep(p0 time.Duration) {
+	s.Recorder.Record("Sleep", p0)
+}
+
+// RegisterStubs registers the stubs of Mailer and Clock (see containertest.RegisterStub).
+func RegisterStubs(stubs *containertest.Stubs) {
+	containertest.RegisterStub(stubs, func(r *containertest.Recorder) Mailer {
+		return MailerStub{r}
+	})
+	containertest.RegisterStub(stubs, func(r *containertest.Recorder) Clock {
+		return ClockStub{r}
+	})
+}
@@ -0,0 +1,38 @@
+// Package example is a sample application tested with the stubs that containerstub generates.
+package example
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+//go:generate go run github.com/golobby/container/cmd/containerstub -type Mailer,Clock
+
+// Sender sends messages.
+type Sender interface {
+	Send(ctx context.Context, to string, body string) error
+}
+
+// Mailer sends mails and reports the failed ones.
+type Mailer interface {
+	Sender
+	Failed(ids ...int) []string
+}
+
+// Clock tells the time.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// Notifier notifies the users with the mailer.
+type Notifier struct {
+	Mailer Mailer
+	Clock  Clock
+}
+
+// Notify sends the user a mail with the current time.
+func (n *Notifier) Notify(ctx context.Context, user string) error {
+	return n.Mailer.Send(ctx, user, fmt.Sprintf("Hello at %s", n.Clock.Now().Format(time.Kitchen)))
+}
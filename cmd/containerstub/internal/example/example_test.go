@@ -0,0 +1,36 @@
+package example_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golobby/container/cmd/containerstub/internal/example"
+	"github.com/golobby/container/v4"
+	"github.com/golobby/container/v4/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStubs_It_Should_Record_The_Calls(t *testing.T) {
+	stubs := containertest.NewStubs()
+	example.RegisterStubs(stubs)
+
+	c := container.New(stubs.Option())
+	err := c.TransientLazy(func(m example.Mailer, clock example.Clock) *example.Notifier {
+		return &example.Notifier{Mailer: m, Clock: clock}
+	})
+	assert.NoError(t, err)
+
+	var n *example.Notifier
+	assert.NoError(t, c.Resolve(&n))
+	assert.NoError(t, n.Notify(context.Background(), "alice"))
+	assert.Nil(t, n.Mailer.Failed(1, 2))
+
+	calls := containertest.StubCalls[example.Mailer](stubs)
+	assert.Len(t, calls, 2)
+	assert.Equal(t, "Send", calls[0].Method)
+	assert.Equal(t, "alice", calls[0].Arguments[1])
+	assert.Equal(t, "Hello at 12:00AM", calls[0].Arguments[2])
+	assert.Equal(t, containertest.Call{Method: "Failed", Arguments: []interface{}{[]int{1, 2}}}, calls[1])
+
+	assert.Equal(t, []containertest.Call{{Method: "Now"}}, containertest.StubCalls[example.Clock](stubs))
+}
@@ -0,0 +1,35 @@
+// Command containerstub generates recording stubs of interfaces for the test mode of the containertest package.
+//
+// Go cannot add methods to types at runtime, so the interfaces that the test mode stubs need stub types.
+// For each interface, it writes a stub type whose methods record their calls and return zero values,
+// and a function that registers the stubs (see containertest.RegisterStub). Example:
+//
+//	//go:generate go run github.com/golobby/container/cmd/containerstub -type Mailer,Clock
+//
+// The stubs are written to a _test.go file by default, so they are only compiled in the package tests.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated names of the interfaces")
+	function := flag.String("func", "RegisterStubs", "name of the generated registration function")
+	output := flag.String("output", "container_stubs_test.go", "output file name, relative to the package directory")
+	dir := flag.String("dir", ".", "package directory")
+	flag.Parse()
+
+	if *typeNames == "" {
+		fmt.Fprintln(os.Stderr, "containerstub: the -type flag is required")
+		os.Exit(2)
+	}
+
+	if err := write(*dir, strings.Split(*typeNames, ","), *function, *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,27 @@
+package invalid
+
+import "reflect"
+
+type Config struct{}
+
+type Store[T any] interface {
+	Get() T
+}
+
+type Number interface {
+	~int | ~float64
+}
+
+type Logger interface {
+	Log(message string)
+}
+
+type LoggerStub struct{}
+
+type Printer interface {
+	Print(message string) error
+}
+
+type Kind interface {
+	reflect.Type
+}
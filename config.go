@@ -0,0 +1,272 @@
+package container
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigSource provides the configuration values by their keys, like "db.url".
+type ConfigSource interface {
+	// Lookup returns the value of the key, or false if the source does not have it.
+	Lookup(key string) (string, bool)
+}
+
+// ConfigFunc is a function that implements ConfigSource.
+type ConfigFunc func(key string) (string, bool)
+
+// Lookup calls the function.
+func (f ConfigFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// MapSource returns a ConfigSource of the given values.
+func MapSource(values map[string]string) ConfigSource {
+	return ConfigFunc(func(key string) (string, bool) {
+		value, exist := values[key]
+		return value, exist
+	})
+}
+
+// EnvSource returns a ConfigSource of the environment variables.
+// The keys are converted to the variable names by upper-casing them, replacing dots and dashes with underscores,
+// and adding the prefix and an underscore (if the prefix is not empty), e.g. "db.url" is APP_DB_URL for the prefix APP.
+func EnvSource(prefix string) ConfigSource {
+	replacer := strings.NewReplacer(".", "_", "-", "_")
+
+	return ConfigFunc(func(key string) (string, bool) {
+		name := strings.ToUpper(replacer.Replace(key))
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+		return os.LookupEnv(name)
+	})
+}
+
+// FlagSource returns a ConfigSource of the flags that are set in the flag set, by their names like "-db.url".
+// The default values of the flags that are not set are ignored, so the other sources can provide them.
+// It uses the command-line flags if the flag set is nil.
+func FlagSource(flags *flag.FlagSet) ConfigSource {
+	if flags == nil {
+		flags = flag.CommandLine
+	}
+
+	return ConfigFunc(func(key string) (value string, exist bool) {
+		flags.Visit(func(f *flag.Flag) {
+			if f.Name == key {
+				value, exist = f.Value.String(), true
+			}
+		})
+		return value, exist
+	})
+}
+
+// JSONSource returns a ConfigSource of the JSON document.
+// The keys of nested objects are joined with dots, e.g. {"db": {"url": "..."}} has the "db.url" key.
+// The arrays of scalars are joined with commas, and the elements of other arrays are keyed by their indexes.
+func JSONSource(data []byte) (ConfigSource, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("container: cannot parse the JSON config: %w", err)
+	}
+
+	return documentSource(document), nil
+}
+
+// DocumentSource returns a ConfigSource of the document that the unmarshal function parses, like yaml.Unmarshal,
+// with the same keys as JSONSource. The function must parse the document into the given *interface{}.
+func DocumentSource(data []byte, unmarshal func(data []byte, v interface{}) error) (ConfigSource, error) {
+	var document interface{}
+	if err := unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("container: cannot parse the config: %w", err)
+	}
+
+	return documentSource(document), nil
+}
+
+// JSONFile returns a ConfigSource of the JSON file (see JSONSource).
+func JSONFile(path string) (ConfigSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("container: cannot read the config: %w", err)
+	}
+	return JSONSource(data)
+}
+
+// DocumentFile returns a ConfigSource of the file that the unmarshal function parses (see DocumentSource).
+func DocumentFile(path string, unmarshal func(data []byte, v interface{}) error) (ConfigSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("container: cannot read the config: %w", err)
+	}
+	return DocumentSource(data, unmarshal)
+}
+
+// documentSource returns a ConfigSource of the flattened values of a parsed document.
+func documentSource(document interface{}) ConfigSource {
+	values := map[string]string{}
+	flatten(values, "", document)
+	return MapSource(values)
+}
+
+// flatten adds the values of the document node to the map, keyed by their dotted paths.
+func flatten(values map[string]string, key string, node interface{}) {
+	join := func(k string) string {
+		if key == "" {
+			return k
+		}
+		return key + "." + k
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			flatten(values, join(k), v)
+		}
+	case map[interface{}]interface{}:
+		for k, v := range n {
+			flatten(values, join(fmt.Sprint(k)), v)
+		}
+	case []interface{}:
+		scalars := make([]string, 0, len(n))
+		for i, v := range n {
+			switch v.(type) {
+			case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+				flatten(values, join(strconv.Itoa(i)), v)
+			default:
+				scalars = append(scalars, fmt.Sprint(v))
+			}
+		}
+		if len(scalars) == len(n) {
+			values[key] = strings.Join(scalars, ",")
+		}
+	case nil:
+		values[key] = ""
+	default:
+		values[key] = fmt.Sprint(n)
+	}
+}
+
+// config returns the value of the key from the first config source that has it.
+func (c Container) config(key string) (string, bool) {
+	for _, source := range c.settings().configs {
+		if value, exist := source.Lookup(key); exist {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// configValue returns the value of a config field, converted to the field type.
+// It returns false if the field is optional and the sources have no value and the field has no default.
+func (c Container) configValue(f field, prefix string) (reflect.Value, bool, error) {
+	key := f.config
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+
+	value, exist := c.config(key)
+	if !exist {
+		if !f.hasDefault {
+			if f.optional {
+				return reflect.Value{}, false, nil
+			}
+			return reflect.Value{}, false, fmt.Errorf("container: no config value found for: %s (%v field)", key, f.label)
+		}
+		value = f.defaultValue
+	}
+
+	converted, err := convert(value, f.t)
+	if err != nil {
+		return reflect.Value{}, false, fmt.Errorf("container: cannot convert config %s to %s (%v field): %w", key, f.t, f.label, err)
+	}
+
+	return converted, true, nil
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// convert converts the config value to the given type.
+// It supports strings, booleans, numbers, durations, the types that implement encoding.TextUnmarshaler,
+// pointers to them, and slices of them separated by commas.
+func convert(value string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+		return v, err
+	}
+
+	if t == durationType {
+		d, err := time.ParseDuration(value)
+		v.SetInt(int64(d))
+		return v, err
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 0, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(value, 0, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(f)
+	case reflect.Pointer:
+		elem, err := convert(value, t.Elem())
+		if err != nil {
+			return v, err
+		}
+		v.Set(reflect.New(t.Elem()))
+		v.Elem().Set(elem)
+	case reflect.Slice:
+		var items []string
+		if value != "" {
+			items = strings.Split(value, ",")
+		}
+		v.Set(reflect.MakeSlice(t, len(items), len(items)))
+		for i, item := range items {
+			elem, err := convert(strings.TrimSpace(item), t.Elem())
+			if err != nil {
+				return v, err
+			}
+			v.Index(i).Set(elem)
+		}
+	default:
+		return v, errors.New("unsupported type")
+	}
+
+	return v, nil
+}
@@ -0,0 +1,231 @@
+package container_test
+
+import (
+	"errors"
+	"flag"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type DatabaseConfig struct {
+	URL     string        `container:"config=url"`
+	Port    int           `container:"config=port,default=3306"`
+	Timeout time.Duration `container:"config=timeout,default=5s"`
+	Debug   bool          `container:"config=debug,optional"`
+	Hosts   []net.IP      `container:"config=hosts,default=127.0.0.1, ::1"`
+	Ratio   *float64      `container:"config=ratio,optional"`
+	Ignored string
+}
+
+func TestContainer_Fill_With_Config(t *testing.T) {
+	c := container.New(container.WithConfig(
+		container.MapSource(map[string]string{"db.url": "mysql://primary", "db.debug": "true"}),
+		container.MapSource(map[string]string{"db.url": "mysql://ignored", "db.ratio": "0.5", "db.timeout": "1m"}),
+	))
+
+	s := struct {
+		URL     string        `container:"config=db.url"`
+		Debug   bool          `container:"config=db.debug"`
+		Ratio   *float64      `container:"config=db.ratio"`
+		Timeout time.Duration `container:"config=db.timeout"`
+		Sizes   []uint16      `container:"config=db.sizes,default=1,2,3"`
+		Empty   []string      `container:"config=db.empty,default="`
+		Name    string        `container:"config=db.name,optional"`
+	}{}
+	assert.NoError(t, c.Fill(&s))
+
+	assert.Equal(t, "mysql://primary", s.URL)
+	assert.True(t, s.Debug)
+	assert.Equal(t, 0.5, *s.Ratio)
+	assert.Equal(t, time.Minute, s.Timeout)
+	assert.Equal(t, []uint16{1, 2, 3}, s.Sizes)
+	assert.Empty(t, s.Empty)
+	assert.Equal(t, "", s.Name)
+}
+
+func TestContainer_Fill_With_Invalid_Config(t *testing.T) {
+	c := container.New(container.WithConfig(container.MapSource(map[string]string{"port": "http"})))
+
+	missing := struct {
+		URL string `container:"config=url"`
+	}{}
+	assert.EqualError(t, c.Fill(&missing), "container: no config value found for: url (URL field)")
+
+	invalid := struct {
+		Port int `container:"config=port"`
+	}{}
+	assert.EqualError(t, c.Fill(&invalid),
+		`container: cannot convert config port to int (Port field): strconv.ParseInt: parsing "http": invalid syntax`)
+
+	unsupported := struct {
+		Port map[string]int `container:"config=port"`
+	}{}
+	assert.EqualError(t, c.Fill(&unsupported),
+		"container: cannot convert config port to map[string]int (Port field): unsupported type")
+
+	tag := struct {
+		Port int `container:"config=port,omit"`
+	}{}
+	assert.EqualError(t, c.Fill(&tag), "container: Port has an invalid struct tag")
+}
+
+func TestContainer_In_With_Config(t *testing.T) {
+	c := container.New(container.WithConfig(container.MapSource(map[string]string{"url": "mysql://localhost"})))
+
+	type Parameters struct {
+		container.In
+
+		URL string `container:"config=url"`
+		S   Shape  `container:"type"`
+	}
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 5}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(p Parameters) Database {
+		assert.Equal(t, "mysql://localhost", p.URL)
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	infos := c.Bindings(container.BindingsOf(databaseType))
+	assert.Equal(t, []container.Dependency{{Type: shapeType}}, infos[0].Dependencies)
+	assert.NoError(t, c.Validate())
+
+	_, err = c.Build()
+	assert.NoError(t, err)
+}
+
+func TestBindConfig(t *testing.T) {
+	c := container.New(container.WithConfig(container.MapSource(map[string]string{
+		"db.url":  "mysql://localhost",
+		"db.port": "3307",
+	})))
+
+	assert.NoError(t, container.BindConfig[DatabaseConfig](c, "db"))
+	assert.NoError(t, container.BindConfig[*DatabaseConfig](c, "db"))
+
+	var config DatabaseConfig
+	assert.NoError(t, c.Resolve(&config))
+	assert.Equal(t, "mysql://localhost", config.URL)
+	assert.Equal(t, 3307, config.Port)
+	assert.Equal(t, 5*time.Second, config.Timeout)
+	assert.Equal(t, []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")}, config.Hosts)
+	assert.Nil(t, config.Ratio)
+
+	var pointer *DatabaseConfig
+	assert.NoError(t, c.Resolve(&pointer))
+	assert.Equal(t, config, *pointer)
+}
+
+func TestBindConfig_With_Invalid_Config(t *testing.T) {
+	c := container.New()
+
+	err := container.BindConfig[DatabaseConfig](c, "db")
+	assert.EqualError(t, err, "container: no config value found for: db.url (URL field)")
+
+	err = container.BindConfig[string](c, "db")
+	assert.EqualError(t, err, "container: the config must be a structure or a pointer to a structure")
+}
+
+func TestEnvSource(t *testing.T) {
+	t.Setenv("APP_DB_URL", "mysql://env")
+	t.Setenv("READ_TIMEOUT", "1s")
+
+	value, exist := container.EnvSource("APP").Lookup("db.url")
+	assert.True(t, exist)
+	assert.Equal(t, "mysql://env", value)
+
+	value, exist = container.EnvSource("").Lookup("read-timeout")
+	assert.True(t, exist)
+	assert.Equal(t, "1s", value)
+
+	_, exist = container.EnvSource("APP").Lookup("db.port")
+	assert.False(t, exist)
+}
+
+func TestFlagSource(t *testing.T) {
+	flags := flag.NewFlagSet("app", flag.ContinueOnError)
+	flags.String("db.url", "mysql://default", "")
+	flags.Int("db.port", 3306, "")
+	assert.NoError(t, flags.Parse([]string{"-db.port", "3307"}))
+
+	source := container.FlagSource(flags)
+
+	value, exist := source.Lookup("db.port")
+	assert.True(t, exist)
+	assert.Equal(t, "3307", value)
+
+	_, exist = source.Lookup("db.url")
+	assert.False(t, exist)
+}
+
+func TestJSONSource_And_DocumentSource(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(jsonPath, []byte(`{
+		"db": {"url": "mysql://json", "port": 3306, "debug": true, "ratio": 0.5, "tags": ["a", "b"], "none": null},
+		"servers": [{"host": "a"}, {"host": "b"}]
+	}`), 0o600))
+
+	// unmarshal parses the documents like the YAML parsers, with int numbers and non-string map keys.
+	unmarshal := func(data []byte, v interface{}) error {
+		if string(data) != "document" {
+			return errors.New("invalid document")
+		}
+		*v.(*interface{}) = map[interface{}]interface{}{
+			"db": map[interface{}]interface{}{
+				"url": "mysql://document", "port": 3306, "debug": true, "ratio": 0.5, "tags": []interface{}{"a", "b"}, "none": nil,
+			},
+			"servers": []interface{}{map[string]interface{}{"host": "a"}, map[string]interface{}{"host": "b"}},
+		}
+		return nil
+	}
+
+	documentPath := filepath.Join(dir, "config.document")
+	assert.NoError(t, os.WriteFile(documentPath, []byte("document"), 0o600))
+
+	jsonSource, err := container.JSONFile(jsonPath)
+	assert.NoError(t, err)
+	documentSource, err := container.DocumentFile(documentPath, unmarshal)
+	assert.NoError(t, err)
+
+	for format, source := range map[string]container.ConfigSource{"json": jsonSource, "document": documentSource} {
+		expected := map[string]string{
+			"db.url":         "mysql://" + format,
+			"db.port":        "3306",
+			"db.debug":       "true",
+			"db.ratio":       "0.5",
+			"db.tags":        "a,b",
+			"db.none":        "",
+			"servers.0.host": "a",
+			"servers.1.host": "b",
+		}
+		for key, value := range expected {
+			actual, exist := source.Lookup(key)
+			assert.True(t, exist, key)
+			assert.Equal(t, value, actual, key)
+		}
+
+		_, exist := source.Lookup("servers")
+		assert.False(t, exist)
+	}
+
+	_, err = container.JSONSource([]byte("{"))
+	assert.Error(t, err)
+	_, err = container.DocumentSource([]byte("a: [b"), unmarshal)
+	assert.EqualError(t, err, "container: cannot parse the config: invalid document")
+	_, err = container.JSONFile(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+	_, err = container.DocumentFile(filepath.Join(dir, "missing.document"), unmarshal)
+	assert.Error(t, err)
+}
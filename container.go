@@ -5,60 +5,308 @@ package container
 import (
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
 // binding holds a resolver and a concrete (if already resolved).
 // It is the break for the Container wall!
 type binding struct {
-	resolver    interface{} // resolver is the function that is responsible for making the concrete.
-	concrete    interface{} // concrete is the stored instance for singleton bindings.
-	isSingleton bool        // isSingleton is true if the binding is a singleton.
+	resolver     interface{}   // resolver is the function that is responsible for making the concrete.
+	concrete     interface{}   // concrete is the stored instance for singleton bindings.
+	isSingleton  bool          // isSingleton is true if the binding is a singleton.
+	isLazy       bool          // isLazy is true if the binding resolver is not called at the binding time.
+	source       *binding      // source makes the concretes when the resolver provides more than one abstraction.
+	index        int           // index is the position of the concrete in the source concretes.
+	site         Site          // site is where the binding is made.
+	plan         *plan         // plan is the precomputed resolution of built containers.
+	value        reflect.Value // value is the singleton concrete converted to the abstraction type.
+	onStart      []hook        // onStart are the hooks that the Start method calls with the concrete.
+	onStop       []hook        // onStop are the hooks that the Stop method calls with the concrete.
+	healthChecks []hook        // healthChecks are the functions that the Health method calls with the concrete.
+
+	factory func(c Container) interface{} // factory makes the concrete of factory bindings, which resolve their arguments with c.
+
+	mu          sync.Mutex  // mu guards making the singleton concrete and its value.
+	isMade      atomic.Bool // isMade is true if the singleton concrete is made, so it is read without locking.
+	isConverted atomic.Bool // isConverted is true if the singleton value is cached, so it is read without locking.
+}
+
+// clone returns a copy of the binding that shares the made concrete but not the binding state.
+func (b *binding) clone() *binding {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cp := &binding{
+		resolver:     b.resolver,
+		concrete:     b.concrete,
+		isSingleton:  b.isSingleton,
+		isLazy:       b.isLazy,
+		source:       b.source,
+		index:        b.index,
+		site:         b.site,
+		plan:         b.plan,
+		value:        b.value,
+		onStart:      slices.Clip(b.onStart),
+		onStop:       slices.Clip(b.onStop),
+		healthChecks: slices.Clip(b.healthChecks),
+		factory:      b.factory,
+	}
+	cp.isMade.Store(b.concrete != nil)
+
+	return cp
 }
 
 // make resolves the binding if needed and returns the resolved concrete.
+// Singleton bindings are made once even if they are resolved concurrently.
 func (b *binding) make(c Container) (interface{}, error) {
-	if b.concrete != nil {
+	if b.isMade.Load() {
 		return b.concrete, nil
 	}
 
-	retVal, err := c.invoke(b.resolver)
-	if b.isSingleton {
-		b.concrete = retVal
+	if !b.isSingleton {
+		return b.resolve(c)
+	}
+
+	if !b.mu.TryLock() {
+		// The binding is being made by another goroutine, or by this one through a circular dependency,
+		// which would wait for itself forever.
+		if err := c.cycle(b); err != nil {
+			return nil, err
+		}
+		b.mu.Lock()
+	}
+	defer b.mu.Unlock()
+
+	if b.concrete == nil {
+		concrete, err := b.resolve(c)
+		if err != nil {
+			return nil, err
+		}
+		b.concrete = concrete
+	}
+
+	if b.concrete != nil {
+		b.isMade.Store(true)
+	}
+
+	return b.concrete, nil
+}
+
+// resolve calls the resolver (or makes the source) and returns the concrete.
+func (b *binding) resolve(c Container) (interface{}, error) {
+	if b.source != nil {
+		concretes, err := b.source.make(c)
+		if err != nil {
+			return nil, err
+		}
+		return concretes.([]interface{})[b.index], nil
+	}
+
+	if b.factory != nil {
+		return b.factory(c), nil
+	}
+
+	if b.plan != nil {
+		return b.plan.invoke(c)
+	}
+
+	return c.invoke(b.resolver)
+}
+
+// makeValue resolves the binding if needed and returns the concrete as a value of the abstraction type.
+// It caches the value of singleton concretes, so they are not converted to the abstraction type again.
+func (b *binding) makeValue(c Container, abstraction reflect.Type) (reflect.Value, error) {
+	if b.isConverted.Load() {
+		return b.value, nil
+	}
+
+	instance, err := b.make(c)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if instance == nil {
+		return reflect.Zero(abstraction), nil
+	}
+
+	if !b.isSingleton {
+		return reflect.ValueOf(instance), nil
 	}
 
-	return retVal, err
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.value.IsValid() {
+		b.value = reflect.New(abstraction).Elem()
+		b.value.Set(reflect.ValueOf(instance))
+	}
+	b.isConverted.Store(true)
+
+	return b.value, nil
+}
+
+// In is embedded in structures that the Container fills when they are resolver or receiver arguments.
+// The structure fields are tagged like the ones that the `Fill` method fills.
+type In struct{}
+
+// Out is embedded in structures that resolvers return.
+// Each field of the structure is bound as a separate abstraction with the resolver lifetime.
+// The `container:"name"` tag binds the field with its name.
+type Out struct{}
+
+var (
+	inType    = reflect.TypeOf(In{})
+	outType   = reflect.TypeOf(Out{})
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// embeds checks if the given type is a structure that embeds the marker type.
+func embeds(t reflect.Type, marker reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Anonymous && t.Field(i).Type == marker {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Container holds the bindings and provides methods to interact with them.
 // It is the entry point in the package.
-type Container map[reflect.Type]map[string]*binding
+type Container struct {
+	bindings   map[reflect.Type]map[string]*binding // bindings maps abstractions and names to bindings.
+	options    *options                             // options holds the settings of the Container.
+	overriding bool                                 // overriding is true if the Container replaces existing bindings.
+	isBuilt    bool                                 // isBuilt is true if the Container is read-only (see Build).
+	lifecycle  *lifecycle                           // lifecycle holds the started components (see Start).
+	parent     *Container                           // parent is the Container that a scope falls back to (see Scope).
+}
+
+// errBuilt is the error of changing the bindings of a built Container.
+var errBuilt = errors.New("container: the container is built and its bindings cannot be changed")
+
+// errNotInitialized is the error of binding in a Container that is not created by New, like `Container{}`.
+var errNotInitialized = errors.New("container: the container is not initialized - it must be created with New()")
 
 // New creates a new concrete of the Container.
-func New() Container {
-	return make(Container)
+func New(opts ...Option) Container {
+	c := Container{
+		bindings:  make(map[reflect.Type]map[string]*binding),
+		options:   &options{hookTimeout: defaultHookTimeout, healthTimeout: defaultHealthTimeout},
+		lifecycle: &lifecycle{},
+	}
+	for _, opt := range opts {
+		opt(c.options)
+	}
+	return c
+}
+
+// settings returns the options of the Container, or the default ones if it is not created by New.
+func (c Container) settings() *options {
+	if c.options == nil {
+		return &options{hookTimeout: defaultHookTimeout, healthTimeout: defaultHealthTimeout}
+	}
+	return c.options
+}
+
+// checkMutable returns an error if the bindings of the Container cannot be changed.
+func (c Container) checkMutable() error {
+	if c.isBuilt {
+		return errBuilt
+	}
+	if c.bindings == nil {
+		return errNotInitialized
+	}
+	return nil
+}
+
+// register stores the binding for the given abstraction and name.
+func (c Container) register(abstraction reflect.Type, name string, b *binding) {
+	if b.concrete != nil {
+		b.isMade.Store(true)
+	}
+
+	if _, exist := c.bindings[abstraction]; !exist {
+		c.bindings[abstraction] = make(map[string]*binding)
+	}
+	c.bindings[abstraction][name] = b
+}
+
+// checkDuplicate applies the duplicate policy if the given abstraction and name are already bound.
+func (c Container) checkDuplicate(abstraction reflect.Type, name string, site Site) error {
+	existing, exist := c.bindings[abstraction][name]
+	if !exist || c.overriding {
+		return nil
+	}
+
+	err := &DuplicateError{Abstraction: abstraction, Name: name, Existing: existing.site, Duplicate: site}
+	switch c.settings().duplicatePolicy {
+	case RejectDuplicates:
+		return err
+	case WarnDuplicates:
+		if c.settings().duplicateHook != nil {
+			c.settings().duplicateHook(err)
+		} else {
+			log.Println(err)
+		}
+	}
+
+	return nil
 }
 
 // bind maps an abstraction to concrete and instantiates if it is a singleton binding.
 func (c Container) bind(resolver interface{}, name string, isSingleton bool, isLazy bool) error {
+	site := callerSite()
+
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
 	reflectedResolver := reflect.TypeOf(resolver)
 	if reflectedResolver.Kind() != reflect.Func {
 		return errors.New("container: the resolver must be a function")
 	}
 
-	if reflectedResolver.NumOut() > 0 {
-		if _, exist := c[reflectedResolver.Out(0)]; !exist {
-			c[reflectedResolver.Out(0)] = make(map[string]*binding)
+	if err := validateResolverFunction(reflectedResolver); err != nil {
+		return err
+	}
+
+	if embeds(reflectedResolver.Out(0), outType) {
+		return c.bindOut(resolver, name, isSingleton, isLazy, site)
+	}
+
+	if abstractions := returnedAbstractions(reflectedResolver); len(abstractions) > 1 {
+		names := make([]string, len(abstractions))
+		for i := range names {
+			names[i] = name
 		}
+
+		return c.bindSplit(resolver, abstractions, names, func(values []reflect.Value) []interface{} {
+			concretes := make([]interface{}, len(values))
+			for i, value := range values {
+				concretes[i] = value.Interface()
+			}
+			return concretes
+		}, isSingleton, isLazy, site)
 	}
 
-	if err := c.validateResolverFunction(reflectedResolver); err != nil {
+	if err := c.checkDuplicate(reflectedResolver.Out(0), name, site); err != nil {
 		return err
 	}
 
 	var concrete interface{}
-	if !isLazy {
+	if c.isEager(isSingleton, isLazy) {
 		var err error
 		concrete, err = c.invoke(resolver)
 		if err != nil {
@@ -67,24 +315,78 @@ func (c Container) bind(resolver interface{}, name string, isSingleton bool, isL
 	}
 
 	if isSingleton {
-		c[reflectedResolver.Out(0)][name] = &binding{resolver: resolver, concrete: concrete, isSingleton: isSingleton}
+		c.register(reflectedResolver.Out(0), name, &binding{
+			resolver:    resolver,
+			concrete:    concrete,
+			isSingleton: isSingleton,
+			isLazy:      isLazy,
+			site:        site,
+		})
 	} else {
-		c[reflectedResolver.Out(0)][name] = &binding{resolver: resolver, isSingleton: isSingleton}
+		c.register(reflectedResolver.Out(0), name, &binding{
+			resolver:    resolver,
+			isSingleton: isSingleton,
+			isLazy:      isLazy,
+			site:        site,
+		})
 	}
 
 	return nil
 }
 
-func (c Container) validateResolverFunction(funcType reflect.Type) error {
+// isEager checks if the resolver of a binding is called at the binding time.
+// Non-lazy singletons are made by the Start method instead if the Container defers singletons.
+func (c Container) isEager(isSingleton bool, isLazy bool) bool {
+	return !isLazy && !(isSingleton && c.settings().deferSingletons)
+}
+
+// returnedAbstractions returns the types of the abstractions that the resolver function returns.
+// The last returned value is not an abstraction if it is an error.
+func returnedAbstractions(funcType reflect.Type) []reflect.Type {
 	retCount := funcType.NumOut()
+	if retCount > 1 && funcType.Out(retCount-1) == errorType {
+		retCount--
+	}
 
-	if retCount == 0 || retCount > 2 {
-		return errors.New("container: resolver function signature is invalid - it must return abstract, or abstract and error")
+	abstractions := make([]reflect.Type, retCount)
+	for i := range abstractions {
+		abstractions[i] = funcType.Out(i)
+	}
+
+	return abstractions
+}
+
+func validateResolverFunction(funcType reflect.Type) error {
+	if funcType.NumOut() == 0 {
+		return errors.New("container: resolver function signature is invalid - it must return abstracts, and optionally an error")
+	}
+
+	resolveTypes := returnedAbstractions(funcType)
+	if embeds(funcType.Out(0), outType) {
+		if len(resolveTypes) > 1 {
+			return errors.New("container: resolver function signature is invalid - it must return only the Out structure, and optionally an error")
+		}
+
+		resolveTypes = nil
+		for i := 0; i < funcType.Out(0).NumField(); i++ {
+			resolveTypes = append(resolveTypes, funcType.Out(0).Field(i).Type)
+		}
+	}
+
+	returned := map[reflect.Type]bool{}
+	for _, resolveType := range resolveTypes {
+		if len(resolveTypes) > 1 && resolveType == errorType {
+			return errors.New("container: resolver function signature is invalid - it returns an error in the middle")
+		}
+		returned[resolveType] = true
+	}
+
+	if !embeds(funcType.Out(0), outType) && len(returned) < len(resolveTypes) {
+		return errors.New("container: resolver function signature is invalid - it returns an abstract more than once")
 	}
 
-	resolveType := funcType.Out(0)
 	for i := 0; i < funcType.NumIn(); i++ {
-		if funcType.In(i) == resolveType {
+		if returned[funcType.In(i)] {
 			return fmt.Errorf("container: resolver function signature is invalid - depends on abstract it returns")
 		}
 	}
@@ -92,6 +394,126 @@ func (c Container) validateResolverFunction(funcType reflect.Type) error {
 	return nil
 }
 
+// bindOut binds the fields of the Out structure that the resolver returns.
+func (c Container) bindOut(resolver interface{}, name string, isSingleton bool, isLazy bool, site Site) error {
+	structure := reflect.TypeOf(resolver).Out(0)
+
+	var fields []int
+	var abstractions []reflect.Type
+	var names []string
+	provided := map[string]string{}
+	for i := 0; i < structure.NumField(); i++ {
+		field := structure.Field(i)
+		if field.Anonymous && field.Type == outType {
+			continue
+		}
+
+		fieldName := name
+		switch t, _ := field.Tag.Lookup("container"); t {
+		case "", "type":
+		case "name":
+			fieldName = field.Name
+		default:
+			return fmt.Errorf("container: %v has an invalid struct tag", field.Name)
+		}
+
+		key := label(field.Type, fieldName)
+		if other, exist := provided[key]; exist {
+			return fmt.Errorf("container: %v and %v fields provide %s more than once", other, field.Name, key)
+		}
+		provided[key] = field.Name
+
+		fields = append(fields, i)
+		abstractions = append(abstractions, field.Type)
+		names = append(names, fieldName)
+	}
+
+	return c.bindSplit(resolver, abstractions, names, func(values []reflect.Value) []interface{} {
+		s := reflect.New(structure).Elem()
+		s.Set(values[0])
+
+		concretes := make([]interface{}, len(fields))
+		for k, i := range fields {
+			f := s.Field(i)
+			concretes[k] = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem().Interface()
+		}
+		return concretes
+	}, isSingleton, isLazy, site)
+}
+
+// bindSplit binds the abstractions that the resolver provides together with their names.
+// The split function splits the resolver returned values into the concretes of the abstractions.
+// The bindings share the resolver lifetime, so singleton resolvers are called only once for all the abstractions.
+func (c Container) bindSplit(
+	resolver interface{},
+	abstractions []reflect.Type,
+	names []string,
+	split func([]reflect.Value) []interface{},
+	isSingleton bool,
+	isLazy bool,
+	site Site,
+) error {
+	for i, abstraction := range abstractions {
+		if err := c.checkDuplicate(abstraction, names[i], site); err != nil {
+			return err
+		}
+	}
+
+	source := &binding{resolver: c.splitter(resolver, split), isSingleton: isSingleton, isLazy: isLazy, site: site}
+
+	if c.isEager(isSingleton, isLazy) {
+		if _, err := source.make(c); err != nil {
+			return err
+		}
+	}
+
+	for i, abstraction := range abstractions {
+		c.register(abstraction, names[i], &binding{
+			resolver:    resolver,
+			isSingleton: isSingleton,
+			isLazy:      isLazy,
+			source:      source,
+			index:       i,
+			site:        site,
+		})
+	}
+
+	return nil
+}
+
+// splitter wraps a resolver with a function of the same arguments that returns the concretes split by the split function.
+// The wrapper returns the resolver error instead, if there is any.
+func (c Container) splitter(resolver interface{}, split func([]reflect.Value) []interface{}) interface{} {
+	reflectedResolver := reflect.TypeOf(resolver)
+
+	parameters := make([]reflect.Type, reflectedResolver.NumIn())
+	for i := range parameters {
+		parameters[i] = reflectedResolver.In(i)
+	}
+
+	concretesType := reflect.TypeOf([]interface{}{})
+	splitterType := reflect.FuncOf(parameters, []reflect.Type{concretesType, errorType}, reflectedResolver.IsVariadic())
+
+	return reflect.MakeFunc(splitterType, func(in []reflect.Value) []reflect.Value {
+		var values []reflect.Value
+		if reflectedResolver.IsVariadic() {
+			values = reflect.ValueOf(resolver).CallSlice(in)
+		} else {
+			values = reflect.ValueOf(resolver).Call(in)
+		}
+
+		last := values[len(values)-1]
+		if last.Type() == errorType {
+			if !last.IsNil() {
+				return []reflect.Value{reflect.Zero(concretesType), last}
+			}
+			values = values[:len(values)-1]
+		}
+
+		return []reflect.Value{reflect.ValueOf(split(values)), reflect.Zero(errorType)}
+	}).Interface()
+}
+
 // invoke calls a function and its returned values.
 // It only accepts one value and an optional error.
 func (c Container) invoke(function interface{}) (interface{}, error) {
@@ -100,7 +522,11 @@ func (c Container) invoke(function interface{}) (interface{}, error) {
 		return nil, err
 	}
 
-	values := reflect.ValueOf(function).Call(arguments)
+	return result(reflect.ValueOf(function).Call(arguments))
+}
+
+// result returns the concrete and the optional error of the values that a resolver returns.
+func result(values []reflect.Value) (interface{}, error) {
 	if len(values) == 2 && values[1].CanInterface() {
 		if err, ok := values[1].Interface().(error); ok {
 			return values[0].Interface(), err
@@ -116,25 +542,263 @@ func (c Container) arguments(function interface{}) ([]reflect.Value, error) {
 	arguments := make([]reflect.Value, argumentsCount)
 
 	for i := 0; i < argumentsCount; i++ {
-		abstraction := reflectedFunction.In(i)
-		if concrete, exist := c[abstraction][""]; exist {
-			instance, err := concrete.make(c)
-			if err != nil {
-				return nil, err
-			}
-			arguments[i] = reflect.ValueOf(instance)
-		} else {
-			return nil, errors.New("container: no concrete found for: " + abstraction.String())
+		argument, err := c.argument(reflectedFunction.In(i))
+		if err != nil {
+			return nil, err
 		}
+		arguments[i] = argument
 	}
 
 	return arguments, nil
 }
 
+// argument returns the resolved argument for an abstraction.
+// It fills the abstraction fields if it is an In structure.
+func (c Container) argument(abstraction reflect.Type) (reflect.Value, error) {
+	if embeds(abstraction, inType) {
+		s := reflect.New(abstraction).Elem()
+		if err := c.fill(s, ""); err != nil {
+			return reflect.Value{}, err
+		}
+		return s, nil
+	}
+
+	if concrete, owner, exist := c.lookup(abstraction, ""); exist {
+		return concrete.makeValue(owner, abstraction)
+	}
+
+	if stub, ok := c.stub(abstraction, ""); ok {
+		return stub, nil
+	}
+
+	return reflect.Value{}, errors.New("container: no concrete found for: " + abstraction.String())
+}
+
+// stub returns the stub of a missing binding in the test mode (see WithStubs).
+func (c Container) stub(abstraction reflect.Type, name string) (reflect.Value, bool) {
+	if c.settings().stub == nil {
+		return reflect.Value{}, false
+	}
+
+	concrete, ok := c.settings().stub(abstraction, name)
+	if !ok || concrete == nil || !reflect.TypeOf(concrete).AssignableTo(abstraction) {
+		return reflect.Value{}, false
+	}
+
+	value := reflect.New(abstraction).Elem()
+	value.Set(reflect.ValueOf(concrete))
+
+	return value, true
+}
+
+// instance binds an abstraction to an already made concrete in singleton mode.
+func (c Container) instance(abstraction reflect.Type, name string, concrete interface{}) error {
+	site := callerSite()
+
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	if concrete == nil {
+		return errors.New("container: the instance must not be nil")
+	}
+
+	if err := c.checkDuplicate(abstraction, name, site); err != nil {
+		return err
+	}
+
+	c.register(abstraction, name, &binding{concrete: concrete, isSingleton: true, site: site})
+
+	return nil
+}
+
+// factory binds a factory function built from the resolver.
+// The first given number of the resolver arguments are left for the caller, and the rest are injected.
+// The factory is bound as a singleton and its type is a function of the arguments left for the caller
+// that returns the abstraction and an error.
+func (c Container) factory(resolver interface{}, name string, parameters int) error {
+	site := callerSite()
+
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	reflectedResolver := reflect.TypeOf(resolver)
+	if reflectedResolver == nil || reflectedResolver.Kind() != reflect.Func {
+		return errors.New("container: the resolver must be a function")
+	}
+
+	if err := validateResolverFunction(reflectedResolver); err != nil {
+		return err
+	}
+
+	if len(returnedAbstractions(reflectedResolver)) > 1 || embeds(reflectedResolver.Out(0), outType) {
+		return errors.New("container: factory resolver function signature is invalid - it must return abstract, or abstract and error")
+	}
+
+	argumentsCount := reflectedResolver.NumIn()
+	if parameters < 0 || parameters > argumentsCount {
+		return fmt.Errorf("container: the factory resolver has %d arguments, so it cannot have %d parameters", argumentsCount, parameters)
+	}
+
+	in := make([]reflect.Type, parameters)
+	for i := range in {
+		in[i] = reflectedResolver.In(i)
+	}
+
+	isVariadic := reflectedResolver.IsVariadic() && parameters == argumentsCount
+	factoryType := reflect.FuncOf(in, []reflect.Type{reflectedResolver.Out(0), errorType}, isVariadic)
+
+	if err := c.checkDuplicate(factoryType, name, site); err != nil {
+		return err
+	}
+
+	// makeFactory makes the factory that resolves the injected arguments with the given Container,
+	// so the clones and the built copies of c do not resolve them with c.
+	makeFactory := func(c Container) reflect.Value {
+		return reflect.MakeFunc(factoryType, func(in []reflect.Value) []reflect.Value {
+			fail := func(err error) []reflect.Value {
+				return []reflect.Value{reflect.Zero(reflectedResolver.Out(0)), reflect.ValueOf(&err).Elem()}
+			}
+
+			arguments := make([]reflect.Value, argumentsCount)
+			copy(arguments, in)
+			for i := parameters; i < argumentsCount; i++ {
+				argument, err := c.argument(reflectedResolver.In(i))
+				if err != nil {
+					return fail(err)
+				}
+				arguments[i] = argument
+			}
+
+			var values []reflect.Value
+			if isVariadic {
+				values = reflect.ValueOf(resolver).CallSlice(arguments)
+			} else {
+				values = reflect.ValueOf(resolver).Call(arguments)
+			}
+
+			if len(values) == 2 {
+				return []reflect.Value{values[0], values[1]}
+			}
+			return []reflect.Value{values[0], reflect.Zero(errorType)}
+		})
+	}
+
+	c.register(factoryType, name, &binding{
+		resolver: reflect.MakeFunc(
+			reflect.FuncOf(nil, []reflect.Type{factoryType}, false),
+			func([]reflect.Value) []reflect.Value { return []reflect.Value{makeFactory(c)} },
+		).Interface(),
+		factory: func(c Container) interface{} {
+			return makeFactory(c).Interface()
+		},
+		isSingleton: true,
+		site:        site,
+	})
+
+	return nil
+}
+
+// unbind deletes the binding of the given abstraction and name.
+func (c Container) unbind(abstraction reflect.Type, name string) error {
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	if _, exist := c.bindings[abstraction][name]; !exist {
+		return errors.New("container: no concrete found for: " + abstraction.String())
+	}
+
+	delete(c.bindings[abstraction], name)
+	if len(c.bindings[abstraction]) == 0 {
+		delete(c.bindings, abstraction)
+	}
+
+	return nil
+}
+
+// replace binds the resolver instead of the existing binding of the given abstraction and name.
+// The new binding keeps the lifetime and laziness of the existing one.
+// It closes the existing singleton concrete if dispose is true and the concrete implements io.Closer.
+func (c Container) replace(abstraction reflect.Type, name string, resolver interface{}, dispose bool) error {
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	existing, exist := c.bindings[abstraction][name]
+	if !exist {
+		return errors.New("container: no concrete found for: " + abstraction.String())
+	}
+
+	reflectedResolver := reflect.TypeOf(resolver)
+	if reflectedResolver == nil || reflectedResolver.Kind() != reflect.Func {
+		return errors.New("container: the resolver must be a function")
+	}
+
+	if abstractions := returnedAbstractions(reflectedResolver); len(abstractions) != 1 || abstractions[0] != abstraction {
+		return errors.New("container: the resolver must return " + abstraction.String())
+	}
+
+	if err := c.Override().bind(resolver, name, existing.isSingleton, existing.isLazy); err != nil {
+		return err
+	}
+
+	if dispose && existing.isSingleton {
+		if closer, ok := existing.concrete.(io.Closer); ok {
+			return closer.Close()
+		}
+	}
+
+	return nil
+}
+
+// Override returns the Container that replaces the existing bindings instead of applying the duplicate policy.
+// It is meant for intentional replacements, e.g. `c.Override().Singleton(...)`.
+func (c Container) Override() Container {
+	c.overriding = true
+	return c
+}
+
+// Has checks if there is a binding for the given abstraction type and name, in the Container or its parents.
+func (c Container) Has(abstraction reflect.Type, name string) bool {
+	_, _, exist := c.lookup(abstraction, name)
+	return exist
+}
+
+// lookup finds the binding of the given abstraction and name in the Container or its parents (see Scope).
+// It returns the Container that holds the binding too, which resolves the binding dependencies.
+func (c Container) lookup(abstraction reflect.Type, name string) (*binding, Container, bool) {
+	if b, exist := c.bindings[abstraction][name]; exist {
+		return b, c, true
+	}
+
+	if c.parent != nil {
+		return c.parent.lookup(abstraction, name)
+	}
+
+	return nil, c, false
+}
+
+// Scope creates a child Container that holds its own bindings and falls back to the bindings of c.
+// The bindings of c are resolved with the bindings of c, so its singletons never depend on the scoped ones.
+// The child Container shares the options of c, and its Dispose method disposes only its own singletons.
+func (c Container) Scope() Container {
+	scope := New()
+	scope.options = c.options
+	scope.parent = &c
+	return scope
+}
+
 // Reset deletes all the existing bindings and empties the container.
+// It does nothing if the Container is built.
 func (c Container) Reset() {
-	for k := range c {
-		delete(c, k)
+	if c.isBuilt {
+		return
+	}
+
+	for k := range c.bindings {
+		delete(c.bindings, k)
 	}
 }
 
@@ -190,6 +854,31 @@ func (c Container) NamedTransientLazy(name string, resolver interface{}) error {
 	return c.bind(resolver, name, false, true)
 }
 
+// Instance binds the type of the given value to the value itself in singleton mode.
+// It is useful for already made values like configurations parsed from flags or environment variables.
+func (c Container) Instance(value interface{}) error {
+	return c.instance(reflect.TypeOf(value), "", value)
+}
+
+// NamedInstance binds the type of the given value with the given name to the value itself in singleton mode.
+func (c Container) NamedInstance(name string, value interface{}) error {
+	return c.instance(reflect.TypeOf(value), name, value)
+}
+
+// Factory binds a factory of the abstraction that the resolver returns.
+// The first given number of the resolver arguments (runtime parameters) are left for the caller of the factory,
+// and the rest of them are injected from the Container when the factory is called.
+// For example, the resolver `func(tenant string, db Database) *Repository` with one parameter binds
+// the factory `func(tenant string) (*Repository, error)` that can be resolved like other abstractions.
+func (c Container) Factory(parameters int, resolver interface{}) error {
+	return c.factory(resolver, "", parameters)
+}
+
+// NamedFactory binds a named factory of the abstraction that the resolver returns.
+func (c Container) NamedFactory(name string, parameters int, resolver interface{}) error {
+	return c.factory(resolver, name, parameters)
+}
+
 // Call takes a receiver function with one or more arguments of the abstractions (interfaces).
 // It invokes the receiver function and passes the related concretes.
 func (c Container) Call(function interface{}) error {
@@ -234,15 +923,23 @@ func (c Container) NamedResolve(abstraction interface{}, name string) error {
 	if receiverType.Kind() == reflect.Ptr {
 		elem := receiverType.Elem()
 
-		if concrete, exist := c[elem][name]; exist {
-			if instance, err := concrete.make(c); err == nil {
-				reflect.ValueOf(abstraction).Elem().Set(reflect.ValueOf(instance))
+		if concrete, owner, exist := c.lookup(elem, name); exist {
+			if value, err := concrete.makeValue(owner, elem); err == nil {
+				reflect.ValueOf(abstraction).Elem().Set(value)
 				return nil
 			} else {
-				return fmt.Errorf("container: encountered error while making concrete for: %s. Error encountered: %w", elem.String(), err)
+				return fmt.Errorf(
+					"container: encountered error while making concrete for: %s (bound at %s). Error encountered: %w",
+					elem.String(), concrete.site, err,
+				)
 			}
 		}
 
+		if stub, ok := c.stub(elem, name); ok {
+			reflect.ValueOf(abstraction).Elem().Set(stub)
+			return nil
+		}
+
 		return errors.New("container: no concrete found for: " + elem.String())
 	}
 
@@ -259,41 +956,119 @@ func (c Container) Fill(structure interface{}) error {
 	if receiverType.Kind() == reflect.Ptr {
 		elem := receiverType.Elem()
 		if elem.Kind() == reflect.Struct {
-			s := reflect.ValueOf(structure).Elem()
+			return c.fill(reflect.ValueOf(structure).Elem(), "")
+		}
+	}
 
-			for i := 0; i < s.NumField(); i++ {
-				f := s.Field(i)
+	return errors.New("container: invalid structure")
+}
 
-				if t, exist := s.Type().Field(i).Tag.Lookup("container"); exist {
-					var name string
+// field is a tagged structure field that the Container fills.
+type field struct {
+	index    int          // index is the field index in the structure.
+	name     string       // name is the binding name, the field name for "name" tags and empty for "type" tags.
+	label    string       // label is the field name used in errors.
+	t        reflect.Type // t is the field type.
+	optional bool         // optional is true if the field is skipped when it is not bound.
 
-					if t == "type" {
-						name = ""
-					} else if t == "name" {
-						name = s.Type().Field(i).Name
-					} else {
-						return fmt.Errorf("container: %v has an invalid struct tag", s.Type().Field(i).Name)
-					}
+	config       string // config is the key of the config value of "config=key" tags, empty for the other tags.
+	defaultValue string // defaultValue is the value of the "default=value" option of config tags.
+	hasDefault   bool   // hasDefault is true if the config tag has the "default=value" option.
+}
 
-					if concrete, exist := c[f.Type()][name]; exist {
-						instance, err := concrete.make(c)
-						if err != nil {
-							return err
-						}
+// fields caches the tagged fields of the structure types (reflect.Type to []field or error).
+var fields sync.Map
 
-						ptr := reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
-						ptr.Set(reflect.ValueOf(instance))
+// taggedFields returns the tagged fields of the structure type.
+// The tag value is "type" or "name", optionally followed by ",optional" to skip the field if it is not bound.
+// It is "config=key" for the fields filled from the config sources (see WithConfig), optionally followed
+// by ",optional" to skip the field if the key is missing, or by ",default=value" (that takes the rest of the tag).
+func taggedFields(structure reflect.Type) ([]field, error) {
+	if cached, exist := fields.Load(structure); exist {
+		if err, ok := cached.(error); ok {
+			return nil, err
+		}
+		return cached.([]field), nil
+	}
 
-						continue
-					}
+	var tagged []field
+	for i := 0; i < structure.NumField(); i++ {
+		f := structure.Field(i)
 
-					return fmt.Errorf("container: cannot make %v field", s.Type().Field(i).Name)
+		if t, exist := f.Tag.Lookup("container"); exist {
+			var name string
+
+			if key, isConfig := strings.CutPrefix(t, "config="); isConfig {
+				configField := field{index: i, label: f.Name, t: f.Type}
+				key, configField.defaultValue, configField.hasDefault = strings.Cut(key, ",default=")
+				if !configField.hasDefault {
+					key, configField.optional = strings.CutSuffix(key, ",optional")
+				}
+				if configField.config = key; key == "" || strings.Contains(key, ",") {
+					err := fmt.Errorf("container: %v has an invalid struct tag", f.Name)
+					fields.Store(structure, err)
+					return nil, err
 				}
+
+				tagged = append(tagged, configField)
+				continue
 			}
 
-			return nil
+			optional := strings.HasSuffix(t, ",optional")
+			t = strings.TrimSuffix(t, ",optional")
+			if t == "type" {
+				name = ""
+			} else if t == "name" {
+				name = f.Name
+			} else {
+				err := fmt.Errorf("container: %v has an invalid struct tag", f.Name)
+				fields.Store(structure, err)
+				return nil, err
+			}
+
+			tagged = append(tagged, field{index: i, name: name, label: f.Name, t: f.Type, optional: optional})
 		}
 	}
 
-	return errors.New("container: invalid structure")
+	fields.Store(structure, tagged)
+
+	return tagged, nil
+}
+
+// fill resolves the tagged fields of the given (addressable) structure value.
+// The keys of the config fields are prefixed with the given prefix and a dot, if the prefix is not empty.
+func (c Container) fill(s reflect.Value, prefix string) error {
+	tagged, err := taggedFields(s.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range tagged {
+		var value reflect.Value
+		if field.config != "" {
+			var exist bool
+			if value, exist, err = c.configValue(field, prefix); err != nil {
+				return err
+			}
+			if !exist {
+				continue
+			}
+		} else if concrete, owner, exist := c.lookup(field.t, field.name); exist {
+			if value, err = concrete.makeValue(owner, field.t); err != nil {
+				return err
+			}
+		} else if field.optional {
+			continue
+		} else if stub, ok := c.stub(field.t, field.name); ok {
+			value = stub
+		} else {
+			return fmt.Errorf("container: cannot make %v field", field.label)
+		}
+
+		f := s.Field(field.index)
+		ptr := reflect.NewAt(field.t, unsafe.Pointer(f.UnsafeAddr())).Elem()
+		ptr.Set(value)
+	}
+
+	return nil
 }
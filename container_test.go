@@ -1,10 +1,15 @@
 package container_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/golobby/container/v3"
+	"github.com/golobby/container/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -166,6 +171,34 @@ func TestContainer_SingletonLazy_With_Non_Resolvable_Arguments(t *testing.T) {
 	assert.EqualError(t, err, "container: resolver function signature is invalid - depends on abstract it returns")
 }
 
+func TestContainer_SingletonLazy_With_Circular_Dependency_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	err := c.SingletonLazy(func(d Database) Shape {
+		return &Circle{}
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func(s Shape) Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	errs := make(chan error, 1)
+	go func() {
+		var s Shape
+		errs <- c.Resolve(&s)
+	}()
+
+	select {
+	case err = <-errs:
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "container: circular dependency: container_test.Shape -> container_test.Database -> container_test.Shape")
+	case <-time.After(time.Second):
+		t.Fatal("the resolution of the circular dependency does not return")
+	}
+}
+
 func TestContainer_NamedSingleton(t *testing.T) {
 	err := instance.NamedSingleton("theCircle", func() Shape {
 		return &Circle{a: 13}
@@ -286,14 +319,14 @@ func TestContainer_TransientLazy_With_Resolve_That_Returns_Error(t *testing.T) {
 }
 
 func TestContainer_Transient_With_Resolve_With_Invalid_Signature_It_Should_Fail(t *testing.T) {
-	err := instance.Transient(func() (Shape, Database, error) {
+	err := instance.Transient(func() (Shape, Shape, error) {
 		return nil, nil, nil
 	})
 	assert.Error(t, err, "container: resolver function signature is invalid")
 }
 
 func TestContainer_TransientLazy_With_Resolve_With_Invalid_Signature_It_Should_Fail(t *testing.T) {
-	err := instance.TransientLazy(func() (Shape, Database, error) {
+	err := instance.TransientLazy(func() (Shape, Shape, error) {
 		return nil, nil, nil
 	})
 	assert.Error(t, err, "container: resolver function signature is invalid")
@@ -323,6 +356,446 @@ func TestContainer_NamedTransientLazy(t *testing.T) {
 	assert.Equal(t, sh.GetArea(), 13)
 }
 
+type Config struct {
+	Host string
+}
+
+func TestContainer_Instance(t *testing.T) {
+	instance := container.New()
+
+	config := &Config{Host: "localhost"}
+	err := instance.Instance(config)
+	assert.NoError(t, err)
+
+	var c *Config
+	err = instance.Resolve(&c)
+	assert.NoError(t, err)
+	assert.Same(t, config, c)
+
+	err = instance.Call(func(c *Config) {
+		assert.Same(t, config, c)
+	})
+	assert.NoError(t, err)
+}
+
+func TestContainer_Instance_With_Nil_Value_It_Should_Fail(t *testing.T) {
+	err := instance.Instance(nil)
+	assert.EqualError(t, err, "container: the instance must not be nil")
+}
+
+func TestContainer_NamedInstance(t *testing.T) {
+	instance := container.New()
+
+	err := instance.NamedInstance("primary", Config{Host: "db1"})
+	assert.NoError(t, err)
+
+	err = instance.NamedInstance("replica", Config{Host: "db2"})
+	assert.NoError(t, err)
+
+	var c Config
+	err = instance.NamedResolve(&c, "replica")
+	assert.NoError(t, err)
+	assert.Equal(t, "db2", c.Host)
+}
+
+type Repository struct {
+	tenant string
+	db     Database
+}
+
+func TestContainer_Factory(t *testing.T) {
+	instance := container.New()
+
+	err := instance.Singleton(func() Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	err = instance.Factory(1, func(tenant string, db Database) *Repository {
+		return &Repository{tenant: tenant, db: db}
+	})
+	assert.NoError(t, err)
+
+	var factory func(string) (*Repository, error)
+	err = instance.Resolve(&factory)
+	assert.NoError(t, err)
+
+	r, err := factory("acme")
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", r.tenant)
+	assert.IsType(t, &MySQL{}, r.db)
+
+	err = instance.Call(func(f func(string) (*Repository, error)) {
+		r, err := f("globex")
+		assert.NoError(t, err)
+		assert.Equal(t, "globex", r.tenant)
+	})
+	assert.NoError(t, err)
+}
+
+func TestContainer_Factory_With_Dependency_Bound_Later(t *testing.T) {
+	instance := container.New()
+
+	err := instance.Factory(1, func(tenant string, db Database) *Repository {
+		return &Repository{tenant: tenant, db: db}
+	})
+	assert.NoError(t, err)
+
+	err = instance.Singleton(func() Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	var factory func(string) (*Repository, error)
+	err = instance.Resolve(&factory)
+	assert.NoError(t, err)
+
+	r, err := factory("acme")
+	assert.NoError(t, err)
+	assert.IsType(t, &MySQL{}, r.db)
+}
+
+func TestContainer_Factory_With_Resolver_That_Returns_Error(t *testing.T) {
+	instance := container.New()
+
+	err := instance.Factory(1, func(tenant string) (*Repository, error) {
+		return nil, errors.New("app: error")
+	})
+	assert.NoError(t, err)
+
+	var factory func(string) (*Repository, error)
+	err = instance.Resolve(&factory)
+	assert.NoError(t, err)
+
+	_, err = factory("acme")
+	assert.EqualError(t, err, "app: error")
+}
+
+func TestContainer_Factory_With_Dependency_Missing_In_Chain(t *testing.T) {
+	instance := container.New()
+
+	err := instance.Singleton(func() Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	err = instance.Factory(1, func(tenant string, db Database) *Repository {
+		return &Repository{tenant: tenant, db: db}
+	})
+	assert.NoError(t, err)
+
+	var factory func(string) (*Repository, error)
+	err = instance.Resolve(&factory)
+	assert.NoError(t, err)
+
+	instance.Reset()
+
+	_, err = factory("acme")
+	assert.EqualError(t, err, "container: no concrete found for: container_test.Database")
+}
+
+func TestContainer_Factory_With_Variadic_Parameters(t *testing.T) {
+	instance := container.New()
+
+	err := instance.Factory(1, func(tenants ...string) *Repository {
+		return &Repository{tenant: strings.Join(tenants, ",")}
+	})
+	assert.NoError(t, err)
+
+	var factory func(...string) (*Repository, error)
+	err = instance.Resolve(&factory)
+	assert.NoError(t, err)
+
+	r, err := factory("acme", "globex")
+	assert.NoError(t, err)
+	assert.Equal(t, "acme,globex", r.tenant)
+}
+
+func TestContainer_Factory_With_Invalid_Resolver_It_Should_Fail(t *testing.T) {
+	err := instance.Factory(0, "STRING!")
+	assert.EqualError(t, err, "container: the resolver must be a function")
+
+	err = instance.Factory(0, func() {})
+	assert.EqualError(t, err, "container: resolver function signature is invalid - it must return abstracts, and optionally an error")
+
+	err = instance.Factory(0, func() (Shape, Database) {
+		return nil, nil
+	})
+	assert.EqualError(t, err, "container: factory resolver function signature is invalid - it must return abstract, or abstract and error")
+
+	err = instance.Factory(2, func(tenant string) *Repository {
+		return &Repository{tenant: tenant}
+	})
+	assert.EqualError(t, err, "container: the factory resolver has 1 arguments, so it cannot have 2 parameters")
+}
+
+func TestContainer_NamedFactory(t *testing.T) {
+	instance := container.New()
+
+	err := instance.NamedFactory("repository", 1, func(tenant string) *Repository {
+		return &Repository{tenant: tenant}
+	})
+	assert.NoError(t, err)
+
+	var factory func(string) (*Repository, error)
+	err = instance.NamedResolve(&factory, "repository")
+	assert.NoError(t, err)
+
+	r, err := factory("acme")
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", r.tenant)
+}
+
+func TestContainer_Singleton_With_In_Argument(t *testing.T) {
+	instance := container.New()
+
+	err := instance.Singleton(func() Shape {
+		return &Circle{a: 5}
+	})
+	assert.NoError(t, err)
+
+	err = instance.NamedSingleton("C", func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	type Parameters struct {
+		container.In
+
+		S Shape    `container:"type"`
+		C Shape    `container:"name"`
+		D Database `container:"type,optional"`
+	}
+
+	err = instance.Singleton(func(p Parameters) Database {
+		assert.Equal(t, 5, p.S.GetArea())
+		assert.Equal(t, 13, p.C.GetArea())
+		assert.Nil(t, p.D)
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	err = instance.Call(func(p Parameters) {
+		assert.IsType(t, &MySQL{}, p.D)
+	})
+	assert.NoError(t, err)
+}
+
+func TestContainer_Call_With_Unresolvable_In_Argument(t *testing.T) {
+	instance := container.New()
+
+	type Parameters struct {
+		container.In
+
+		S Shape `container:"type"`
+	}
+
+	err := instance.Call(func(p Parameters) {})
+	assert.EqualError(t, err, "container: cannot make S field")
+}
+
+type Outputs struct {
+	container.Out
+
+	S Shape
+	C Shape `container:"name"`
+	D Database
+}
+
+func TestContainer_Singleton_With_Out_Result(t *testing.T) {
+	instance := container.New()
+
+	calls := 0
+	err := instance.Singleton(func() Outputs {
+		calls++
+		return Outputs{S: &Circle{a: 5}, C: &Circle{a: 13}, D: &MySQL{}}
+	})
+	assert.NoError(t, err)
+
+	var s, c Shape
+	var d Database
+
+	assert.NoError(t, instance.Resolve(&s))
+	assert.NoError(t, instance.NamedResolve(&c, "C"))
+	assert.NoError(t, instance.Resolve(&d))
+
+	assert.Equal(t, 5, s.GetArea())
+	assert.Equal(t, 13, c.GetArea())
+	assert.IsType(t, &MySQL{}, d)
+	assert.Equal(t, 1, calls)
+}
+
+func TestContainer_TransientLazy_With_Out_Result(t *testing.T) {
+	instance := container.New()
+
+	calls := 0
+	err := instance.TransientLazy(func() (Outputs, error) {
+		calls++
+		return Outputs{S: &Circle{a: calls}, C: &Circle{}, D: &MySQL{}}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+
+	var s1, s2 Shape
+	assert.NoError(t, instance.Resolve(&s1))
+	assert.NoError(t, instance.Resolve(&s2))
+	assert.Equal(t, 1, s1.GetArea())
+	assert.Equal(t, 2, s2.GetArea())
+}
+
+func TestContainer_Singleton_With_Out_Result_That_Returns_Error(t *testing.T) {
+	instance := container.New()
+
+	err := instance.Singleton(func() (Outputs, error) {
+		return Outputs{}, errors.New("app: error")
+	})
+	assert.EqualError(t, err, "app: error")
+
+	err = instance.SingletonLazy(func() (Outputs, error) {
+		return Outputs{}, errors.New("app: error")
+	})
+	assert.NoError(t, err)
+
+	var s Shape
+	err = instance.Resolve(&s)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container: encountered error while making concrete for: container_test.Shape (bound at ")
+	assert.Contains(t, err.Error(), "). Error encountered: app: error")
+}
+
+func TestContainer_Singleton_With_Out_Result_With_Invalid_Tag_It_Should_Fail(t *testing.T) {
+	type Invalid struct {
+		container.Out
+
+		S Shape `container:"invalid"`
+	}
+
+	err := instance.Singleton(func() Invalid {
+		return Invalid{}
+	})
+	assert.EqualError(t, err, "container: S has an invalid struct tag")
+}
+
+func TestContainer_Singleton_With_Out_Result_That_Provides_An_Abstraction_Twice_It_Should_Fail(t *testing.T) {
+	type Twice struct {
+		container.Out
+
+		S Shape
+		C Shape `container:"type"`
+	}
+
+	err := container.New().Singleton(func() Twice {
+		return Twice{}
+	})
+	assert.EqualError(t, err, "container: S and C fields provide container_test.Shape more than once")
+
+	err = container.New().NamedSingleton("rounded", func() Twice {
+		return Twice{}
+	})
+	assert.EqualError(t, err, `container: S and C fields provide container_test.Shape (name: "rounded") more than once`)
+}
+
+func TestContainer_Singleton_With_Out_Result_That_Depends_On_Itself_It_Should_Fail(t *testing.T) {
+	err := instance.Singleton(func(s Shape) Outputs {
+		return Outputs{}
+	})
+	assert.EqualError(t, err, "container: resolver function signature is invalid - depends on abstract it returns")
+}
+
+func TestContainer_Singleton_With_Resolver_That_Returns_Multiple_Abstractions(t *testing.T) {
+	instance := container.New()
+
+	calls := 0
+	err := instance.Singleton(func() (Shape, Database, error) {
+		calls++
+		return &Circle{a: 5}, &MySQL{}, nil
+	})
+	assert.NoError(t, err)
+
+	err = instance.Call(func(s Shape, d Database) {
+		assert.Equal(t, 5, s.GetArea())
+		assert.IsType(t, &MySQL{}, d)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestContainer_NamedSingletonLazy_With_Resolver_That_Returns_Multiple_Abstractions(t *testing.T) {
+	instance := container.New()
+
+	calls := 0
+	err := instance.NamedSingletonLazy("main", func() (Shape, Database) {
+		calls++
+		return &Circle{a: 5}, &MySQL{}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+
+	var s Shape
+	var d Database
+	assert.NoError(t, instance.NamedResolve(&s, "main"))
+	assert.NoError(t, instance.NamedResolve(&d, "main"))
+	assert.Equal(t, 1, calls)
+}
+
+func TestContainer_Transient_With_Resolver_That_Returns_Multiple_Abstractions(t *testing.T) {
+	instance := container.New()
+
+	calls := 0
+	err := instance.TransientLazy(func() (Shape, Database) {
+		calls++
+		return &Circle{a: calls}, &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	var s1, s2 Shape
+	assert.NoError(t, instance.Resolve(&s1))
+	assert.NoError(t, instance.Resolve(&s2))
+	assert.Equal(t, 1, s1.GetArea())
+	assert.Equal(t, 2, s2.GetArea())
+}
+
+func TestContainer_Singleton_With_Resolver_That_Returns_Multiple_Abstractions_And_Error(t *testing.T) {
+	err := instance.Singleton(func() (Shape, Database, error) {
+		return nil, nil, errors.New("app: error")
+	})
+	assert.EqualError(t, err, "app: error")
+}
+
+func TestContainer_Singleton_With_Resolver_That_Returns_Error_In_The_Middle_It_Should_Fail(t *testing.T) {
+	err := instance.Singleton(func() (Shape, error, Database) {
+		return nil, nil, nil
+	})
+	assert.EqualError(t, err, "container: resolver function signature is invalid - it returns an error in the middle")
+}
+
+func TestContainer_Singleton_With_Resolver_That_Returns_An_Abstraction_Twice_It_Should_Fail(t *testing.T) {
+	err := instance.Singleton(func() (Shape, Shape) {
+		return nil, nil
+	})
+	assert.EqualError(t, err, "container: resolver function signature is invalid - it returns an abstract more than once")
+}
+
+func TestContainer_Singleton_With_Out_Result_And_Other_Abstractions_It_Should_Fail(t *testing.T) {
+	err := instance.Singleton(func() (Outputs, Database) {
+		return Outputs{}, nil
+	})
+	assert.EqualError(t, err, "container: resolver function signature is invalid - it must return only the Out structure, and optionally an error")
+}
+
+func TestContainer_Has(t *testing.T) {
+	instance := container.New()
+
+	err := instance.NamedSingleton("rounded", func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, instance.Has(shapeType, "rounded"))
+	assert.False(t, instance.Has(shapeType, ""))
+	assert.False(t, instance.Has(databaseType, "rounded"))
+}
+
 func TestContainer_Call_With_Multiple_Resolving(t *testing.T) {
 	err := instance.Singleton(func() Shape {
 		return &Circle{a: 5}
@@ -453,6 +926,24 @@ func TestContainer_Resolve_With_Reference_As_Resolver(t *testing.T) {
 	}
 }
 
+func TestContainer_Resolve_With_Resolver_Error_It_Should_Report_Binding_Site(t *testing.T) {
+	instance := container.New()
+
+	_, file, line, _ := runtime.Caller(0)
+	err := instance.TransientLazy(func() (Shape, error) {
+		return nil, errors.New("app: error")
+	})
+	assert.NoError(t, err)
+
+	var s Shape
+	err = instance.Resolve(&s)
+	assert.EqualError(t, err, fmt.Sprintf(
+		"container: encountered error while making concrete for: container_test.Shape (bound at %s:%d (%s)). "+
+			"Error encountered: app: error",
+		file, line+1, "github.com/golobby/container/v4_test.TestContainer_Resolve_With_Resolver_Error_It_Should_Report_Binding_Site",
+	))
+}
+
 func TestContainer_Resolve_With_Unsupported_Receiver_It_Should_Fail(t *testing.T) {
 	err := instance.Resolve("STRING!")
 	assert.EqualError(t, err, "container: invalid abstraction")
@@ -564,6 +1055,20 @@ func TestContainer_Fill_With_Invalid_Field_Name_It_Should_Fail(t *testing.T) {
 	assert.EqualError(t, err, "container: cannot make S field")
 }
 
+func TestContainer_Fill_With_Optional_Field(t *testing.T) {
+	instance := container.New()
+
+	myApp := struct {
+		S Shape `container:"type,optional"`
+		C Shape `container:"name,optional"`
+	}{}
+
+	err := instance.Fill(&myApp)
+	assert.NoError(t, err)
+	assert.Nil(t, myApp.S)
+	assert.Nil(t, myApp.C)
+}
+
 func TestContainer_Fill_With_Invalid_Struct_It_Should_Fail(t *testing.T) {
 	invalidStruct := 0
 	err := instance.Fill(&invalidStruct)
@@ -607,3 +1112,153 @@ func TestContainer_Fill_With_Dependency_Missing_In_Chain(t *testing.T) {
 	err = instance.Fill(&myApp)
 	assert.EqualError(t, err, "container: no concrete found for: container_test.Shape")
 }
+
+type Level1 struct{ s Shape }
+type Level2 struct{ l *Level1 }
+type Level3 struct{ l *Level2 }
+type Level4 struct{ l *Level3 }
+
+func BenchmarkContainer_Resolve_Singleton(b *testing.B) {
+	c := container.New()
+	container.MustSingleton(c, func() Shape {
+		return &Circle{a: 13}
+	})
+
+	var s Shape
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Resolve(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContainer_Resolve_Transient(b *testing.B) {
+	c := container.New()
+	container.MustTransient(c, func() Shape {
+		return &Circle{a: 13}
+	})
+
+	var s Shape
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Resolve(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContainer_Resolve_Deep_Chain(b *testing.B) {
+	c := container.New()
+	container.MustSingleton(c, func() Shape { return &Circle{a: 13} })
+	container.MustTransient(c, func(s Shape) *Level1 { return &Level1{s: s} })
+	container.MustTransient(c, func(l *Level1) *Level2 { return &Level2{l: l} })
+	container.MustTransient(c, func(l *Level2) *Level3 { return &Level3{l: l} })
+	container.MustTransient(c, func(l *Level3) *Level4 { return &Level4{l: l} })
+
+	var l *Level4
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Resolve(&l); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContainer_NamedResolve(b *testing.B) {
+	c := container.New()
+	container.MustNamedSingleton(c, "rounded", func() Shape {
+		return &Circle{a: 13}
+	})
+
+	var s Shape
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.NamedResolve(&s, "rounded"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContainer_Call(b *testing.B) {
+	c := container.New()
+	container.MustSingleton(c, func() Shape { return &Circle{a: 13} })
+	container.MustSingleton(c, func() Database { return &MySQL{} })
+
+	receiver := func(s Shape, d Database) {}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Call(receiver); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContainer_Fill(b *testing.B) {
+	c := container.New()
+	container.MustSingleton(c, func() Shape { return &Circle{a: 13} })
+	container.MustNamedSingleton(c, "C", func() Shape { return &Circle{a: 13} })
+	container.MustSingleton(c, func() Database { return &MySQL{} })
+
+	myApp := struct {
+		S Shape    `container:"type"`
+		C Shape    `container:"name"`
+		D Database `container:"type"`
+		X string
+	}{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Fill(&myApp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestContainer_Zero_Value(t *testing.T) {
+	var c container.Container
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.EqualError(t, err, "container: the container is not initialized - it must be created with New()")
+
+	assert.Error(t, c.Instance(&Circle{}))
+	assert.Error(t, c.Factory(0, func() Shape { return &Circle{} }))
+	assert.Error(t, container.Unbind[Shape](c, ""))
+
+	var s Shape
+	assert.EqualError(t, c.Resolve(&s), "container: no concrete found for: container_test.Shape")
+
+	assert.NoError(t, c.Validate())
+	assert.NoError(t, c.Start(context.Background()))
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.NoError(t, c.Dispose())
+	assert.Empty(t, c.Bindings())
+	assert.Equal(t, container.HealthUp, c.Health(context.Background()).Status)
+
+	built, err := c.Build()
+	assert.NoError(t, err)
+	assert.NoError(t, built.Validate())
+
+	scope := c.Scope()
+	err = scope.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, scope.Resolve(&s))
+
+	clone := c.Clone()
+	assert.NoError(t, clone.Instance(&Circle{}))
+}
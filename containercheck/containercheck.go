@@ -0,0 +1,250 @@
+// Package containercheck defines an analyzer that reports misuses of the container package
+// that compile fine but fail at runtime, like resolving into non-pointers and invalid resolver signatures.
+package containercheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// containerPath is the import path of the container package.
+const containerPath = "github.com/golobby/container/v4"
+
+// Analyzer reports misuses of the container package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "containercheck",
+	Doc:      "report misuses of the github.com/golobby/container package that fail at runtime",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// binders are the functions and methods that take resolvers as their last arguments.
+var binders = map[string]bool{
+	"Singleton":          true,
+	"SingletonLazy":      true,
+	"NamedSingleton":     true,
+	"NamedSingletonLazy": true,
+	"Transient":          true,
+	"TransientLazy":      true,
+	"NamedTransient":     true,
+	"NamedTransientLazy": true,
+	"Factory":            true,
+	"NamedFactory":       true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// The container tags are checked only in the packages that import the container package,
+	// since other libraries use the same tag key.
+	hasContainer := importsContainer(pass.Pkg)
+
+	nodes := []ast.Node{(*ast.CallExpr)(nil), (*ast.StructType)(nil)}
+	inspect.Preorder(nodes, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			checkCall(pass, node)
+		case *ast.StructType:
+			if hasContainer {
+				checkTags(pass, node)
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// importsContainer checks if the package is the container package or imports it.
+func importsContainer(pkg *types.Package) bool {
+	if pkg.Path() == containerPath {
+		return true
+	}
+
+	for _, imported := range pkg.Imports() {
+		if imported.Path() == containerPath {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkCall checks the arguments of the calls to the container functions and methods.
+func checkCall(pass *analysis.Pass, call *ast.CallExpr) {
+	f, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || f.Pkg() == nil || f.Pkg().Path() != containerPath {
+		return
+	}
+
+	name := f.Name()
+	args := call.Args
+	if strings.HasPrefix(name, "Must") && f.Type().(*types.Signature).Recv() == nil {
+		name = strings.TrimPrefix(name, "Must")
+		if len(args) > 0 {
+			args = args[1:]
+		}
+	}
+	if len(args) == 0 {
+		return
+	}
+
+	switch {
+	case binders[name]:
+		checkResolver(pass, args[len(args)-1], strings.HasSuffix(name, "Factory"))
+	case name == "Resolve" || name == "NamedResolve":
+		if t := pass.TypesInfo.TypeOf(args[0]); t != nil && !isInterface(t) {
+			if _, ok := t.Underlying().(*types.Pointer); !ok {
+				pass.Reportf(args[0].Pos(), "container: invalid abstraction - %s takes a pointer, not %s", name, t)
+			}
+		}
+	case name == "Fill":
+		if t := pass.TypesInfo.TypeOf(args[0]); t != nil && !isInterface(t) {
+			if p, ok := t.Underlying().(*types.Pointer); !ok || !isStruct(p.Elem()) {
+				pass.Reportf(args[0].Pos(), "container: invalid structure - Fill takes a pointer to a struct, not %s", t)
+			}
+		}
+	case name == "Call":
+		checkReceiver(pass, args[0])
+	}
+}
+
+// checkResolver checks the resolver against the rules of binding (bind and validateResolverFunction).
+func checkResolver(pass *analysis.Pass, resolver ast.Expr, isFactory bool) {
+	t := pass.TypesInfo.TypeOf(resolver)
+	if t == nil || isInterface(t) {
+		return
+	}
+
+	signature, ok := t.Underlying().(*types.Signature)
+	if !ok {
+		pass.Reportf(resolver.Pos(), "container: the resolver must be a function, not %s", t)
+		return
+	}
+
+	results := signature.Results()
+	if results.Len() == 0 {
+		pass.Reportf(resolver.Pos(), "container: resolver function signature is invalid - it must return abstracts, and optionally an error")
+		return
+	}
+
+	count := results.Len()
+	if count > 1 && isError(results.At(count-1).Type()) {
+		count--
+	}
+
+	if isFactory && count > 1 {
+		pass.Reportf(resolver.Pos(), "container: factory resolver function signature is invalid - it must return abstract, or abstract and error")
+		return
+	}
+
+	var abstractions []types.Type
+	for i := 0; i < count; i++ {
+		abstraction := results.At(i).Type()
+		if count > 1 && isError(abstraction) {
+			pass.Reportf(resolver.Pos(), "container: resolver function signature is invalid - it returns an error in the middle")
+			return
+		}
+		for _, other := range abstractions {
+			if types.Identical(other, abstraction) {
+				pass.Reportf(resolver.Pos(), "container: resolver function signature is invalid - it returns an abstract more than once")
+				return
+			}
+		}
+		abstractions = append(abstractions, abstraction)
+	}
+
+	for i := 0; i < signature.Params().Len(); i++ {
+		for _, abstraction := range abstractions {
+			if types.Identical(signature.Params().At(i).Type(), abstraction) {
+				pass.Reportf(resolver.Pos(), "container: resolver function signature is invalid - depends on abstract it returns")
+				return
+			}
+		}
+	}
+}
+
+// checkReceiver checks the receiver function of the Call method.
+func checkReceiver(pass *analysis.Pass, receiver ast.Expr) {
+	t := pass.TypesInfo.TypeOf(receiver)
+	if t == nil || isInterface(t) {
+		return
+	}
+
+	signature, ok := t.Underlying().(*types.Signature)
+	if !ok {
+		pass.Reportf(receiver.Pos(), "container: invalid function - Call takes a function, not %s", t)
+		return
+	}
+
+	results := signature.Results()
+	if results.Len() > 1 || (results.Len() == 1 && !isError(results.At(0).Type())) {
+		pass.Reportf(receiver.Pos(), "container: receiver function signature is invalid - it must return nothing or an error")
+	}
+}
+
+// checkTags checks the container tags of the struct fields.
+// The fields of Out structs are checked against the tags of the Out results, and the others against the tags of Fill.
+func checkTags(pass *analysis.Pass, structure *ast.StructType) {
+	isOut := embedsOut(pass, structure)
+
+	for _, field := range structure.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+
+		value, exist := reflect.StructTag(tag).Lookup("container")
+		if !exist {
+			continue
+		}
+
+		if isOut && !validOutTag(value) {
+			pass.Reportf(field.Tag.Pos(), "container: invalid struct tag %q - the fields of Out structs must be type or name", value)
+		} else if !isOut && !validTag(value) {
+			pass.Reportf(field.Tag.Pos(), "container: invalid struct tag %q - it must be type, name, or config=key, optionally followed by ,optional", value)
+		}
+	}
+}
+
+// embedsOut checks if the struct embeds the Out structure of the container package.
+func embedsOut(pass *analysis.Pass, structure *ast.StructType) bool {
+	for _, field := range structure.Fields.List {
+		if len(field.Names) > 0 {
+			continue
+		}
+
+		named, ok := pass.TypesInfo.TypeOf(field.Type).(*types.Named)
+		if ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == containerPath && named.Obj().Name() == "Out" {
+			return true
+		}
+	}
+	return false
+}
+
+// isInterface checks if the type is an interface, like the interface{} arguments that are unknown statically.
+func isInterface(t types.Type) bool {
+	return types.IsInterface(t)
+}
+
+// isStruct checks if the type is a struct.
+func isStruct(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+// isError checks if the type is the error interface.
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,12 @@
+package containercheck_test
+
+import (
+	"testing"
+
+	"github.com/golobby/container/containercheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), containercheck.Analyzer, "a", "b")
+}
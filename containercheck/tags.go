@@ -0,0 +1,31 @@
+package containercheck
+
+import "strings"
+
+// validTag checks if the container tag value is "type", "name", or "config=key", optionally followed by ",optional".
+// The config tags may be followed by ",default=value" instead, which takes the rest of the tag.
+// It follows the tags of the In structures and the Fill method.
+func validTag(value string) bool {
+	if key, isConfig := strings.CutPrefix(value, "config="); isConfig {
+		key, _, hasDefault := strings.Cut(key, ",default=")
+		if !hasDefault {
+			key = strings.TrimSuffix(key, ",optional")
+		}
+		return key != "" && !strings.Contains(key, ",")
+	}
+
+	switch strings.TrimSuffix(value, ",optional") {
+	case "type", "name":
+		return true
+	}
+	return false
+}
+
+// validOutTag checks if the container tag value of an Out structure field is empty, "type", or "name".
+func validOutTag(value string) bool {
+	switch value {
+	case "", "type", "name":
+		return true
+	}
+	return false
+}
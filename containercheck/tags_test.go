@@ -0,0 +1,54 @@
+package containercheck
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// tags are the container tag values that the analyzer and the container must agree on.
+var tags = []string{
+	"", "type", "name", "type,optional", "name,optional", "optional", ",optional", "kind", "name,", "type,name",
+	"config=db.url", "config=db.port,default=3306", "config=db.tags,default=a,b", "config=db.query,optional",
+	"config=", "config=db.url,omit", "config=a,b", "config=,default=x",
+}
+
+// tagged returns a struct field with the given container tag.
+func tagged(value string) reflect.StructField {
+	return reflect.StructField{
+		Name: "F",
+		Type: reflect.TypeOf(""),
+		Tag:  reflect.StructTag("container:" + strconv.Quote(value)),
+	}
+}
+
+func TestValidTag_It_Should_Agree_With_Fill(t *testing.T) {
+	c := container.New(container.WithConfig(container.MapSource(nil)))
+
+	for _, value := range tags {
+		structure := reflect.StructOf([]reflect.StructField{tagged(value)})
+
+		err := c.Fill(reflect.New(structure).Interface())
+		isInvalid := err != nil && strings.Contains(err.Error(), "invalid struct tag")
+		assert.Equal(t, !isInvalid, validTag(value), "tag %q", value)
+	}
+}
+
+func TestValidOutTag_It_Should_Agree_With_Out_Results(t *testing.T) {
+	out := reflect.StructField{Name: "Out", Type: reflect.TypeOf(container.Out{}), Anonymous: true}
+
+	for _, value := range tags {
+		structure := reflect.StructOf([]reflect.StructField{out, tagged(value)})
+		resolver := reflect.MakeFunc(reflect.FuncOf(nil, []reflect.Type{structure}, false), func([]reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.New(structure).Elem()}
+		})
+
+		err := container.New().SingletonLazy(resolver.Interface())
+		isInvalid := err != nil && strings.Contains(err.Error(), "invalid struct tag")
+		assert.Equal(t, !isInvalid, validOutTag(value), "tag %q", value)
+	}
+}
@@ -0,0 +1,87 @@
+package a
+
+import "github.com/golobby/container/v4"
+
+type Shape interface{ Area() int }
+
+type Circle struct{ a int }
+
+func (c Circle) Area() int { return c.a }
+
+type Database interface{ Connect() bool }
+
+type App struct {
+	S Shape    `container:"type"`
+	D Database `container:"name"`
+	O Shape    `container:"type,optional"`
+	X Shape    `container:"kind"`           // want `container: invalid struct tag "kind"`
+	Y Shape    `json:"y" container:"name,"` // want `container: invalid struct tag "name,"`
+	U string   `container:"config=db.url"`
+	P int      `container:"config=db.port,default=3306"`
+	T []string `container:"config=db.tags,default=a,b"`
+	Q string   `container:"config=db.query,optional"`
+	E string   `container:"config="`            // want `container: invalid struct tag "config="`
+	K string   `container:"config=db.url,omit"` // want `container: invalid struct tag "config=db.url,omit"`
+	Z Shape    `json:"z"`
+}
+
+type Outputs struct {
+	container.Out
+
+	S Shape    `container:""`
+	C Shape    `container:"name"`
+	D Database `container:"type"`
+	O Shape    `container:"type,optional"` // want `container: invalid struct tag "type,optional" - the fields of Out structs must be type or name`
+	U string   `container:"config=db.url"` // want `container: invalid struct tag "config=db.url"`
+}
+
+func resolvers() {
+	c := container.New()
+
+	_ = c.Singleton(func() Shape { return Circle{} })
+	_ = c.Singleton(func() (Shape, Database, error) { return nil, nil, nil })
+	_ = c.Singleton(Circle{})                                                           // want `container: the resolver must be a function, not a.Circle`
+	_ = c.Transient(func() {})                                                          // want `must return abstracts, and optionally an error`
+	_ = c.NamedSingleton("a", func() (Shape, error, Database) { return nil, nil, nil }) // want `it returns an error in the middle`
+	_ = c.Singleton(func() (Shape, Shape) { return nil, nil })                          // want `it returns an abstract more than once`
+	_ = c.Singleton(func(s Shape) Shape { return s })                                   // want `depends on abstract it returns`
+	_ = c.Factory(1, func(a int) (Shape, Database) { return nil, nil })                 // want `factory resolver function signature is invalid`
+	_ = container.Singleton(42)                                                         // want `container: the resolver must be a function, not int`
+	_ = container.NamedTransient("a", "resolver")                                       // want `container: the resolver must be a function, not string`
+	container.MustSingleton(c, Circle{})                                                // want `container: the resolver must be a function`
+
+	var resolver interface{} = func() Shape { return Circle{} }
+	_ = c.Singleton(resolver)
+}
+
+func resolves() {
+	c := container.New()
+
+	var s Shape
+	var circle Circle
+	_ = c.Resolve(&s)
+	_ = c.Resolve(s)                           // interfaces may hold pointers, so they are not reported.
+	_ = c.Resolve(circle)                      // want `container: invalid abstraction - Resolve takes a pointer, not a.Circle`
+	_ = c.NamedResolve(Circle{}, "a")          // want `container: invalid abstraction - NamedResolve takes a pointer, not a.Circle`
+	_ = container.Resolve(circle)              // want `Resolve takes a pointer`
+	container.MustNamedResolve(c, circle, "a") // want `NamedResolve takes a pointer`
+
+	var abstraction interface{} = &s
+	_ = c.Resolve(abstraction)
+
+	app := App{}
+	_ = c.Fill(&app)
+	_ = c.Fill(app)        // want `container: invalid structure - Fill takes a pointer to a struct, not a.App`
+	_ = container.Fill(&s) // want `Fill takes a pointer to a struct, not \*a.Shape`
+}
+
+func calls() {
+	c := container.New()
+
+	_ = c.Call(func(s Shape) {})
+	_ = c.Call(func(s Shape) error { return nil })
+	_ = c.Call(func(s Shape) int { return 0 })         // want `it must return nothing or an error`
+	_ = c.Call(func() (int, error) { return 0, nil })  // want `it must return nothing or an error`
+	_ = container.Call(Circle{})                       // want `Call takes a function, not a.Circle`
+	container.MustCall(c, func() bool { return true }) // want `it must return nothing or an error`
+}
@@ -0,0 +1,7 @@
+package b
+
+// Service uses the container tag key of another library, so it is not checked.
+type Service struct {
+	Cache interface{} `container:"cache,lifetime=request"`
+	Store interface{} `container:"kind"`
+}
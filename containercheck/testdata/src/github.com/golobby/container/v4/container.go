@@ -0,0 +1,29 @@
+// Package container is a stub of the container package for the analyzer tests.
+package container
+
+type Container struct{}
+
+type In struct{}
+
+type Out struct{}
+
+func (c Container) Singleton(resolver interface{}) error                    { return nil }
+func (c Container) NamedSingleton(name string, resolver interface{}) error  { return nil }
+func (c Container) Transient(resolver interface{}) error                    { return nil }
+func (c Container) Factory(parameters int, resolver interface{}) error      { return nil }
+func (c Container) Call(receiver interface{}) error                         { return nil }
+func (c Container) Resolve(abstraction interface{}) error                   { return nil }
+func (c Container) NamedResolve(abstraction interface{}, name string) error { return nil }
+func (c Container) Fill(structure interface{}) error                        { return nil }
+
+func New() Container { return Container{} }
+
+func Singleton(resolver interface{}) error                   { return nil }
+func NamedTransient(name string, resolver interface{}) error { return nil }
+func Resolve(abstraction interface{}) error                  { return nil }
+func Fill(structure interface{}) error                       { return nil }
+func Call(receiver interface{}) error                        { return nil }
+
+func MustSingleton(c Container, resolver interface{})                    {}
+func MustNamedResolve(c Container, abstraction interface{}, name string) {}
+func MustCall(c Container, receiver interface{})                         {}
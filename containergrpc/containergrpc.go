@@ -0,0 +1,189 @@
+// Package containergrpc provides gRPC-style interceptors that give each RPC its own container scope.
+//
+// The interceptors are defined against small local types that mirror the gRPC ones, so the module does not depend
+// on gRPC, and they can be tested in-process. The scope holds the context.Context, the incoming Metadata,
+// and the server info of the RPC, and the RPC-scoped services that the WithServices option binds.
+// It is disposed when the handler returns. With gRPC, the interceptors are adapted like:
+//
+//	interceptor := containergrpc.UnaryInterceptor(c, containergrpc.WithMetadata(func(ctx context.Context) containergrpc.Metadata {
+//		md, _ := metadata.FromIncomingContext(ctx)
+//		return containergrpc.Metadata(md)
+//	}))
+//
+//	grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+//		return interceptor(ctx, req, &containergrpc.UnaryServerInfo{Server: info.Server, FullMethod: info.FullMethod}, containergrpc.UnaryHandler(handler))
+//	})
+package containergrpc
+
+import (
+	"context"
+	"log"
+
+	"github.com/golobby/container/v4"
+)
+
+// Metadata is the incoming metadata of an RPC, like metadata.MD of gRPC.
+type Metadata map[string][]string
+
+// Get returns the values of the key, like metadata.MD.Get of gRPC (the keys are lowercase).
+func (m Metadata) Get(key string) []string {
+	return m[key]
+}
+
+// UnaryServerInfo is the information of a unary RPC, like grpc.UnaryServerInfo.
+type UnaryServerInfo struct {
+	Server     interface{} // Server is the service implementation.
+	FullMethod string      // FullMethod is the full RPC method string, i.e., /package.service/method.
+}
+
+// UnaryHandler handles a unary RPC, like grpc.UnaryHandler.
+type UnaryHandler func(ctx context.Context, req interface{}) (interface{}, error)
+
+// UnaryServerInterceptor intercepts unary RPCs, like grpc.UnaryServerInterceptor.
+type UnaryServerInterceptor func(ctx context.Context, req interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error)
+
+// StreamServerInfo is the information of a streaming RPC, like grpc.StreamServerInfo.
+type StreamServerInfo struct {
+	FullMethod     string // FullMethod is the full RPC method string, i.e., /package.service/method.
+	IsClientStream bool   // IsClientStream is true if the client streams.
+	IsServerStream bool   // IsServerStream is true if the server streams.
+}
+
+// ServerStream is the part of grpc.ServerStream that the stream interceptor uses.
+type ServerStream interface {
+	Context() context.Context
+	SendMsg(m interface{}) error
+	RecvMsg(m interface{}) error
+}
+
+// StreamHandler handles a streaming RPC, like grpc.StreamHandler.
+// The stream that the interceptor passes returns the RPC scope in its context,
+// so gRPC handlers need a grpc.ServerStream wrapper that returns the same context.
+type StreamHandler func(srv interface{}, stream ServerStream) error
+
+// StreamServerInterceptor intercepts streaming RPCs, like grpc.StreamServerInterceptor.
+type StreamServerInterceptor func(srv interface{}, stream ServerStream, info *StreamServerInfo, handler StreamHandler) error
+
+// options holds the settings of the interceptors.
+type options struct {
+	metadata func(ctx context.Context) Metadata                           // metadata extracts the incoming metadata.
+	services []func(scope container.Container, ctx context.Context) error // services bind the RPC-scoped services.
+}
+
+// Option configures the interceptors.
+type Option func(*options)
+
+// WithMetadata sets the function that extracts the incoming metadata of the RPC context,
+// like metadata.FromIncomingContext of gRPC. The scopes hold empty metadata without it.
+func WithMetadata(metadata func(ctx context.Context) Metadata) Option {
+	return func(o *options) {
+		o.metadata = metadata
+	}
+}
+
+// WithServices adds a function that binds the RPC-scoped services in the scope of each RPC.
+func WithServices(services func(scope container.Container, ctx context.Context) error) Option {
+	return func(o *options) {
+		o.services = append(o.services, services)
+	}
+}
+
+// newOptions returns the options with the given settings.
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// scope creates the scope of an RPC and returns it with the RPC context that holds it (see container.WithContainer).
+// The scope binds the context, the incoming metadata, the server info, and the RPC-scoped services.
+func (o *options) scope(c container.Container, ctx context.Context, info interface{}) (container.Container, context.Context, error) {
+	scope := c.Scope()
+	ctx = container.WithContainer(ctx, scope)
+
+	metadata := Metadata{}
+	if o.metadata != nil {
+		if md := o.metadata(ctx); md != nil {
+			metadata = md
+		}
+	}
+
+	if err := container.InstanceAs[context.Context](scope, ctx); err != nil {
+		return scope, ctx, err
+	}
+	if err := scope.Instance(metadata); err != nil {
+		return scope, ctx, err
+	}
+	if err := scope.Instance(info); err != nil {
+		return scope, ctx, err
+	}
+
+	for _, service := range o.services {
+		if err := service(scope, ctx); err != nil {
+			return scope, ctx, err
+		}
+	}
+
+	return scope, ctx, nil
+}
+
+// dispose disposes the scope singletons and logs the errors, since the RPC is already handled.
+func dispose(scope container.Container) {
+	if err := scope.Dispose(); err != nil {
+		log.Println("containergrpc:", err)
+	}
+}
+
+// UnaryInterceptor returns an interceptor that creates a child scope of c for each unary RPC (see Container.Scope).
+// The handler receives the context that holds the scope (see container.FromContext).
+// The scope binds the context.Context, the Metadata, the *UnaryServerInfo, and the RPC-scoped services,
+// and it is disposed (see Container.Dispose) when the handler returns.
+func UnaryInterceptor(c container.Container, opts ...Option) UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error) {
+		scope, ctx, err := o.scope(c, ctx, info)
+		defer dispose(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns an interceptor that creates a child scope of c for each streaming RPC (see Container.Scope).
+// The handler receives a stream whose context holds the scope (see container.FromContext).
+// The scope binds the context.Context, the Metadata, the *StreamServerInfo, the ServerStream,
+// and the RPC-scoped services, and it is disposed (see Container.Dispose) when the handler returns.
+func StreamInterceptor(c container.Container, opts ...Option) StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv interface{}, stream ServerStream, info *StreamServerInfo, handler StreamHandler) error {
+		scope, ctx, err := o.scope(c, stream.Context(), info)
+		defer dispose(scope)
+		if err != nil {
+			return err
+		}
+
+		scoped := &scopedStream{ServerStream: stream, ctx: ctx}
+		if err = container.InstanceAs[ServerStream](scope, scoped); err != nil {
+			return err
+		}
+
+		return handler(srv, scoped)
+	}
+}
+
+// scopedStream is a ServerStream whose context holds the RPC scope.
+type scopedStream struct {
+	ServerStream
+	ctx context.Context
+}
+
+// Context returns the context that holds the RPC scope.
+func (s *scopedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,209 @@
+package containergrpc_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/golobby/container/v4/containergrpc"
+	"github.com/stretchr/testify/assert"
+)
+
+type Greeter interface {
+	Greet(name string) string
+}
+
+type English struct{}
+
+func (e English) Greet(name string) string {
+	return "Hello " + name
+}
+
+// Session is an RPC-scoped service.
+type Session struct {
+	user   string
+	closed bool
+}
+
+func (s *Session) Close() error {
+	s.closed = true
+	return nil
+}
+
+// metadataKey is the context key of the test metadata, like the incoming metadata of gRPC.
+type metadataKey struct{}
+
+func incoming(ctx context.Context) containergrpc.Metadata {
+	md, _ := ctx.Value(metadataKey{}).(containergrpc.Metadata)
+	return md
+}
+
+func newContext(user string) context.Context {
+	return context.WithValue(context.Background(), metadataKey{}, containergrpc.Metadata{"user": {user}})
+}
+
+func newContainer(t *testing.T) container.Container {
+	c := container.New()
+	assert.NoError(t, c.Singleton(func() Greeter {
+		return English{}
+	}))
+	return c
+}
+
+func sessions(sessions *[]*Session) containergrpc.Option {
+	return containergrpc.WithServices(func(scope container.Container, ctx context.Context) error {
+		return scope.SingletonLazy(func(md containergrpc.Metadata) *Session {
+			session := &Session{user: md.Get("user")[0]}
+			*sessions = append(*sessions, session)
+			return session
+		})
+	})
+}
+
+func scopeOf(t *testing.T, ctx context.Context) container.Container {
+	scope, ok := container.FromContext(ctx)
+	assert.True(t, ok)
+	return scope
+}
+
+// stream is an in-process ServerStream.
+type stream struct {
+	ctx      context.Context
+	received []interface{}
+	sent     []interface{}
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *stream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *stream) RecvMsg(m interface{}) error {
+	if len(s.received) == 0 {
+		return errors.New("EOF")
+	}
+	*(m.(*string)) = s.received[0].(string)
+	s.received = s.received[1:]
+	return nil
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	c := newContainer(t)
+
+	var created []*Session
+	interceptor := containergrpc.UnaryInterceptor(c, containergrpc.WithMetadata(incoming), sessions(&created))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		var response string
+		err := scopeOf(t, ctx).Call(func(g Greeter, s *Session, info *containergrpc.UnaryServerInfo, scoped context.Context) {
+			assert.Same(t, ctx, scoped)
+			assert.Equal(t, "/greeter.Greeter/Greet", info.FullMethod)
+			response = g.Greet(s.user) + req.(string)
+		})
+		return response, err
+	}
+
+	info := &containergrpc.UnaryServerInfo{FullMethod: "/greeter.Greeter/Greet"}
+	for _, user := range []string{"Alice", "Bob"} {
+		response, err := interceptor(newContext(user), "!", info, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello "+user+"!", response)
+	}
+
+	assert.Len(t, created, 2)
+	assert.True(t, created[0].closed)
+	assert.True(t, created[1].closed)
+	assert.False(t, c.Has(reflect.TypeOf(&Session{}), ""))
+}
+
+func TestUnaryInterceptor_Without_Metadata(t *testing.T) {
+	c := newContainer(t)
+	interceptor := containergrpc.UnaryInterceptor(c)
+
+	response, err := interceptor(context.Background(), nil, &containergrpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		md, err := container.ResolveFromContext[containergrpc.Metadata](ctx)
+		return md, err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, containergrpc.Metadata{}, response)
+}
+
+func TestUnaryInterceptor_With_Services_Error(t *testing.T) {
+	c := newContainer(t)
+	interceptor := containergrpc.UnaryInterceptor(c, containergrpc.WithServices(func(container.Container, context.Context) error {
+		return errors.New("app: unavailable")
+	}))
+
+	called := false
+	_, err := interceptor(context.Background(), nil, &containergrpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	})
+	assert.EqualError(t, err, "app: unavailable")
+	assert.False(t, called)
+}
+
+func TestUnaryInterceptor_With_Handler_Error(t *testing.T) {
+	c := newContainer(t)
+
+	var created []*Session
+	interceptor := containergrpc.UnaryInterceptor(c, containergrpc.WithMetadata(incoming), sessions(&created))
+
+	_, err := interceptor(newContext("Alice"), nil, &containergrpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, err := container.ResolveFromContext[*Session](ctx)
+		assert.NoError(t, err)
+		return nil, errors.New("app: failed")
+	})
+	assert.EqualError(t, err, "app: failed")
+	assert.Len(t, created, 1)
+	assert.True(t, created[0].closed)
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	c := newContainer(t)
+
+	var created []*Session
+	interceptor := containergrpc.StreamInterceptor(c, containergrpc.WithMetadata(incoming), sessions(&created))
+
+	handler := func(srv interface{}, ss containergrpc.ServerStream) error {
+		return scopeOf(t, ss.Context()).Call(func(g Greeter, s *Session, info *containergrpc.StreamServerInfo, scoped containergrpc.ServerStream) error {
+			assert.Same(t, ss, scoped)
+			assert.True(t, info.IsClientStream)
+
+			var name string
+			for ss.RecvMsg(&name) == nil {
+				if err := ss.SendMsg(g.Greet(name) + " from " + s.user); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	ss := &stream{ctx: newContext("Alice"), received: []interface{}{"Bob", "Carol"}}
+	info := &containergrpc.StreamServerInfo{FullMethod: "/greeter.Greeter/GreetAll", IsClientStream: true, IsServerStream: true}
+	assert.NoError(t, interceptor(nil, ss, info, handler))
+	assert.Equal(t, []interface{}{"Hello Bob from Alice", "Hello Carol from Alice"}, ss.sent)
+
+	assert.Len(t, created, 1)
+	assert.True(t, created[0].closed)
+}
+
+func TestStreamInterceptor_With_Services_Error(t *testing.T) {
+	c := newContainer(t)
+	interceptor := containergrpc.StreamInterceptor(c, containergrpc.WithServices(func(container.Container, context.Context) error {
+		return errors.New("app: unavailable")
+	}))
+
+	err := interceptor(nil, &stream{ctx: context.Background()}, &containergrpc.StreamServerInfo{}, func(interface{}, containergrpc.ServerStream) error {
+		t.Fatal("the handler is called")
+		return nil
+	})
+	assert.EqualError(t, err, "app: unavailable")
+}
@@ -0,0 +1,145 @@
+// Package containerhttp provides net/http middleware that gives each request its own container scope,
+// and a handler that serves the health report of a container (see HealthHandler).
+//
+// The scope holds the *http.Request, the http.ResponseWriter and the context.Context of the request,
+// and the request-scoped services that the WithServices option binds. It is disposed when the handler returns.
+//
+//	mux.Handle("/users", containerhttp.Handler(func(w http.ResponseWriter, r *http.Request, users UserRepository) {
+//		// ...
+//	}))
+//	http.ListenAndServe(":8080", containerhttp.Middleware(c)(mux))
+package containerhttp
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/golobby/container/v4"
+)
+
+// options holds the settings of the middleware.
+type options struct {
+	services     []func(scope container.Container, r *http.Request) error // services bind the request-scoped services.
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)  // errorHandler responds to the scope errors.
+}
+
+// Option configures the middleware.
+type Option func(*options)
+
+// WithServices adds a function that binds the request-scoped services in the scope of each request.
+func WithServices(services func(scope container.Container, r *http.Request) error) Option {
+	return func(o *options) {
+		o.services = append(o.services, services)
+	}
+}
+
+// WithErrorHandler sets the function that responds when the request-scoped services cannot be bound.
+// The default handler responds with the 500 status code.
+func WithErrorHandler(handler func(w http.ResponseWriter, r *http.Request, err error)) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
+
+// defaultErrorHandler responds to the errors with the 500 status code.
+func defaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// Middleware returns a middleware that creates a child scope of c for each request (see Container.Scope).
+// The scope binds the *http.Request, http.ResponseWriter, and context.Context of the request,
+// and it is stored in the request context (see FromContext and container.WithContainer).
+// The scope singletons are disposed (see Container.Dispose) when the handler returns.
+func Middleware(c container.Container, opts ...Option) func(http.Handler) http.Handler {
+	o := &options{errorHandler: defaultErrorHandler}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := c.Scope()
+
+			r = r.WithContext(container.WithContainer(r.Context(), scope))
+
+			defer func() {
+				if err := scope.Dispose(); err != nil {
+					log.Println("containerhttp:", err)
+				}
+			}()
+
+			if err := bind(scope, w, r, o.services); err != nil {
+				o.errorHandler(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bind binds the request values and the request-scoped services in the scope.
+func bind(
+	scope container.Container,
+	w http.ResponseWriter,
+	r *http.Request,
+	services []func(scope container.Container, r *http.Request) error,
+) error {
+	if err := bindRequest(scope, w, r); err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		if err := service(scope, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bindRequest binds the response writer, the request, and its context in the scope.
+// It replaces the existing ones, since the middlewares between the scope middleware and the handler may wrap them.
+func bindRequest(scope container.Container, w http.ResponseWriter, r *http.Request) error {
+	scope = scope.Override()
+
+	if err := scope.Instance(r); err != nil {
+		return err
+	}
+	if err := container.InstanceAs[http.ResponseWriter](scope, w); err != nil {
+		return err
+	}
+	return container.InstanceAs[context.Context](scope, r.Context())
+}
+
+// FromContext returns the request scope stored in the context by the middleware.
+// It is the same as container.FromContext, so container.ResolveFromContext resolves with the request scope too.
+func FromContext(ctx context.Context) (container.Container, bool) {
+	return container.FromContext(ctx)
+}
+
+// Handler adapts a function like `func(w http.ResponseWriter, r *http.Request, deps...)` to an http.Handler.
+// It calls the function with the request scope (see Container.Call), so the function arguments,
+// including the response writer and the request, are resolved from the scope.
+// The function can return an error. It responds with the 500 status code if the call fails
+// or there is no request scope (the middleware is not used).
+func Handler(function interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := FromContext(r.Context())
+		if !ok {
+			log.Println("containerhttp: no request scope, the handler must be wrapped by the middleware")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		err := bindRequest(scope, w, r)
+		if err == nil {
+			err = scope.Call(function)
+		}
+		if err != nil {
+			log.Println("containerhttp:", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	})
+}
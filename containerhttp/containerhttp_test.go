@@ -0,0 +1,157 @@
+package containerhttp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/golobby/container/v4/containerhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+type Greeter interface {
+	Greet(name string) string
+}
+
+type English struct{}
+
+func (e English) Greet(name string) string {
+	return "Hello " + name
+}
+
+// Session is a request-scoped service.
+type Session struct {
+	user   string
+	closed bool
+}
+
+func (s *Session) Close() error {
+	s.closed = true
+	return nil
+}
+
+func newContainer(t *testing.T) container.Container {
+	c := container.New()
+	assert.NoError(t, c.Singleton(func() Greeter {
+		return English{}
+	}))
+	return c
+}
+
+func TestMiddleware_And_Handler(t *testing.T) {
+	c := newContainer(t)
+
+	var sessions []*Session
+	middleware := containerhttp.Middleware(c, containerhttp.WithServices(func(scope container.Container, r *http.Request) error {
+		return scope.SingletonLazy(func(r *http.Request) *Session {
+			session := &Session{user: r.Header.Get("User")}
+			sessions = append(sessions, session)
+			return session
+		})
+	}))
+
+	handler := containerhttp.Handler(func(w http.ResponseWriter, r *http.Request, g Greeter, s *Session, ctx context.Context) {
+		assert.Same(t, r.Context(), ctx)
+		_, _ = fmt.Fprint(w, g.Greet(s.user))
+	})
+
+	for _, user := range []string{"Alice", "Bob"} {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("User", user)
+		recorder := httptest.NewRecorder()
+
+		middleware(handler).ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "Hello "+user, recorder.Body.String())
+	}
+
+	assert.Len(t, sessions, 2)
+	assert.True(t, sessions[0].closed)
+	assert.True(t, sessions[1].closed)
+	assert.False(t, c.Has(reflect.TypeOf(&Session{}), ""))
+}
+
+func TestFromContext(t *testing.T) {
+	c := newContainer(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := containerhttp.FromContext(r.Context())
+		assert.True(t, ok)
+
+		var request *http.Request
+		assert.NoError(t, scope.Resolve(&request))
+		assert.Same(t, r, request)
+
+		g, err := container.ResolveFromContext[Greeter](r.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello Alice", g.Greet("Alice"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	recorder := httptest.NewRecorder()
+	containerhttp.Middleware(c)(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+
+	_, ok := containerhttp.FromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestMiddleware_With_Failed_Services_It_Should_Respond_With_Error(t *testing.T) {
+	c := newContainer(t)
+
+	var handled error
+	middleware := containerhttp.Middleware(c,
+		containerhttp.WithServices(func(scope container.Container, r *http.Request) error {
+			return errors.New("no session")
+		}),
+		containerhttp.WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			handled = err
+			w.WriteHeader(http.StatusUnauthorized)
+		}),
+	)
+
+	called := false
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	recorder := httptest.NewRecorder()
+	middleware(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.EqualError(t, handled, "no session")
+	assert.False(t, called)
+}
+
+func TestHandler_With_Failed_Call_It_Should_Respond_With_Error(t *testing.T) {
+	c := newContainer(t)
+
+	handlers := []http.Handler{
+		containerhttp.Handler(func(w http.ResponseWriter, s *Session) {}),
+		containerhttp.Handler(func(w http.ResponseWriter) error {
+			return errors.New("handler error")
+		}),
+	}
+
+	for _, handler := range handlers {
+		recorder := httptest.NewRecorder()
+		containerhttp.Middleware(c)(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	}
+}
+
+func TestHandler_Without_Middleware_It_Should_Respond_With_Error(t *testing.T) {
+	called := false
+	handler := containerhttp.Handler(func(w http.ResponseWriter) {
+		called = true
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.False(t, called)
+}
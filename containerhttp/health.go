@@ -0,0 +1,25 @@
+package containerhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/golobby/container/v4"
+)
+
+// HealthHandler returns an http.Handler that serves the health report of c (see Container.Health) as JSON.
+// The status code is 200 if all the checks pass and 503 otherwise.
+func HealthHandler(c container.Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Health(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status == container.HealthUp {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
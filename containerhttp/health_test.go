@@ -0,0 +1,57 @@
+package containerhttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/golobby/container/v4/containerhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+type Pinger struct {
+	err error
+}
+
+func (p *Pinger) CheckHealth(ctx context.Context) error {
+	return p.err
+}
+
+func TestHealthHandler(t *testing.T) {
+	c := container.New()
+
+	pinger := &Pinger{}
+	assert.NoError(t, c.Instance(pinger))
+
+	recorder := httptest.NewRecorder()
+	containerhttp.HealthHandler(c).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var report map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(t, "up", report["status"])
+	assert.Equal(t, "*containerhttp_test.Pinger", report["results"].([]interface{})[0].(map[string]interface{})["type"])
+
+	pinger.err = errors.New("unreachable")
+
+	recorder = httptest.NewRecorder()
+	containerhttp.HealthHandler(c).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"error":"unreachable"`)
+}
+
+func TestHealthHandler_With_Global_Container(t *testing.T) {
+	container.Reset()
+	defer container.Reset()
+
+	assert.NoError(t, container.Instance(&Pinger{}))
+
+	recorder := httptest.NewRecorder()
+	containerhttp.HealthHandler(container.Global).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
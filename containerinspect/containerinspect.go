@@ -0,0 +1,179 @@
+// Package containerinspect prints the bindings and the dependency graph of a Container.
+//
+// It backs the container command, which runs it through a generated main package,
+// and can also be called from a custom main function or a test:
+//
+//	func main() {
+//		containerinspect.Main(app.Register)
+//	}
+package containerinspect
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golobby/container/v4"
+)
+
+// Main binds the dependencies with the register function in a new Container
+// and runs the command of the program arguments (see Run).
+// The Container defers its non-lazy singletons (see container.WithDeferredSingletons) and is never started,
+// so their resolvers do not run, but the resolvers of the non-lazy transients still run at the binding time.
+// It prints the errors to the standard error and exits with a non-zero status on failures.
+func Main(register func(c container.Container) error) {
+	c := container.New(container.WithDeferredSingletons())
+	if err := register(c); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := Run(os.Stdout, c, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Run runs the command of the arguments on the Container and writes the output to w.
+// The commands are:
+//
+//	bindings            prints the bindings table (the default command).
+//	why [-name N] TYPE  prints the dependency trees of the bindings of the type, like "*http.Server".
+//	check               prints the missing and circular dependencies and fails if there is any.
+func Run(w io.Writer, c container.Container, args []string) error {
+	if len(args) == 0 {
+		return Bindings(w, c)
+	}
+
+	switch args[0] {
+	case "bindings":
+		return Bindings(w, c)
+	case "why":
+		flags := flag.NewFlagSet("why", flag.ContinueOnError)
+		flags.SetOutput(w)
+		name := flags.String("name", "", "binding name, all the bindings of the type if empty")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return errors.New("containerinspect: why takes exactly one type")
+		}
+		return Why(w, c, flags.Arg(0), *name)
+	case "check":
+		return Check(w, c)
+	default:
+		return fmt.Errorf("containerinspect: unknown command %q, it must be bindings, why, or check", args[0])
+	}
+}
+
+// Bindings writes the table of the bindings of the Container.
+func Bindings(w io.Writer, c container.Container) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tNAME\tLIFETIME\tLAZY\tRESOLVED\tSITE")
+	for _, info := range c.Bindings() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%t\t%s\n",
+			info.Type, info.Name, info.Lifetime, info.IsLazy, info.IsResolved, info.Site)
+	}
+
+	return tw.Flush()
+}
+
+// Why writes the dependency trees of the bindings of the type (as printed by reflect, like "*http.Server").
+// It writes the trees of all the bindings of the type if the name is empty.
+func Why(w io.Writer, c container.Container, typeName string, name string) error {
+	bindings := map[container.Dependency]container.BindingInfo{}
+	var roots []container.BindingInfo
+	for _, info := range c.Bindings() {
+		bindings[container.Dependency{Type: info.Type, Name: info.Name}] = info
+		if info.Type.String() == typeName && (name == "" || info.Name == name) {
+			roots = append(roots, info)
+		}
+	}
+
+	if len(roots) == 0 {
+		if name != "" {
+			return fmt.Errorf("containerinspect: no binding found for: %s (name: %q)", typeName, name)
+		}
+		return fmt.Errorf("containerinspect: no binding found for: %s", typeName)
+	}
+
+	t := &tree{w: w, bindings: bindings, visiting: map[container.Dependency]bool{}}
+	for _, root := range roots {
+		t.print(container.Dependency{Type: root.Type, Name: root.Name}, "", "")
+	}
+
+	return nil
+}
+
+// tree writes dependency trees.
+type tree struct {
+	w        io.Writer
+	bindings map[container.Dependency]container.BindingInfo
+	visiting map[container.Dependency]bool // visiting holds the dependencies of the current branch to stop at cycles.
+}
+
+// print writes the dependency and its dependencies.
+// The prefix is written before the dependency line and the indent before its dependencies lines.
+func (t *tree) print(dependency container.Dependency, prefix string, indent string) {
+	optional := dependency.Optional
+	dependency.Optional = false
+
+	info, exist := t.bindings[dependency]
+	if !exist {
+		status := "missing"
+		if optional {
+			status = "optional, missing"
+		}
+		fmt.Fprintf(t.w, "%s%s (%s)\n", prefix, dependency, status)
+		return
+	}
+
+	if t.visiting[dependency] {
+		fmt.Fprintf(t.w, "%s%s (circular)\n", prefix, dependency)
+		return
+	}
+
+	details := []string{string(info.Lifetime)}
+	if info.IsLazy {
+		details = append(details, "lazy")
+	}
+	if info.IsResolved {
+		details = append(details, "resolved")
+	}
+	fmt.Fprintf(t.w, "%s%s (%s, bound at %s)\n", prefix, dependency, strings.Join(details, ", "), info.Site)
+
+	t.visiting[dependency] = true
+	defer delete(t.visiting, dependency)
+
+	for i, d := range info.Dependencies {
+		if i == len(info.Dependencies)-1 {
+			t.print(d, indent+"└── ", indent+"    ")
+		} else {
+			t.print(d, indent+"├── ", indent+"│   ")
+		}
+	}
+}
+
+// Check writes the missing and circular dependencies of the Container.
+// It returns an error if there is any.
+func Check(w io.Writer, c container.Container) error {
+	err := c.Validate()
+	if err == nil {
+		fmt.Fprintln(w, "no missing or circular dependencies found")
+		return nil
+	}
+
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, e := range errs {
+		fmt.Fprintln(w, e)
+	}
+
+	return fmt.Errorf("containerinspect: found %d problem(s)", len(errs))
+}
@@ -0,0 +1,157 @@
+package containerinspect_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/golobby/container/v4/containerinspect"
+	"github.com/stretchr/testify/assert"
+)
+
+type Config struct{}
+
+type Database interface{}
+
+type Cache interface{}
+
+type Server struct{}
+
+type Parameters struct {
+	container.In
+
+	DB    Database `container:"type"`
+	Cache Cache    `container:"name,optional"`
+}
+
+func register(c container.Container) error {
+	if err := c.Instance(&Config{}); err != nil {
+		return err
+	}
+	if err := c.SingletonLazy(func(config *Config) Database { return nil }); err != nil {
+		return err
+	}
+	return c.TransientLazy(func(p Parameters, config *Config) *Server { return &Server{} })
+}
+
+// lines returns the output lines without the binding sites.
+func lines(output string) []string {
+	var result []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if i := strings.Index(line, ", bound at "); i != -1 {
+			line = line[:i] + ")"
+		}
+		result = append(result, strings.TrimRight(line, " "))
+	}
+	return result
+}
+
+func TestRun_Bindings(t *testing.T) {
+	c := container.New()
+	assert.NoError(t, register(c))
+
+	var buffer bytes.Buffer
+	assert.NoError(t, containerinspect.Run(&buffer, c, nil))
+
+	output := lines(buffer.String())
+	assert.Len(t, output, 4)
+	assert.Regexp(t, `^TYPE\s+NAME\s+LIFETIME\s+LAZY\s+RESOLVED\s+SITE$`, output[0])
+	assert.Regexp(t, `^\*containerinspect_test.Config\s+singleton\s+false\s+true\s+.*containerinspect_test.go:\d+ `, output[1])
+	assert.Regexp(t, `^\*containerinspect_test.Server\s+transient\s+true\s+false\s+`, output[2])
+	assert.Regexp(t, `^containerinspect_test.Database\s+singleton\s+true\s+false\s+`, output[3])
+}
+
+func TestRun_Why(t *testing.T) {
+	c := container.New()
+	assert.NoError(t, register(c))
+
+	var buffer bytes.Buffer
+	assert.NoError(t, containerinspect.Run(&buffer, c, []string{"why", "*containerinspect_test.Server"}))
+
+	assert.Equal(t, []string{
+		"*containerinspect_test.Server (transient, lazy)",
+		"├── containerinspect_test.Database (singleton, lazy)",
+		"│   └── *containerinspect_test.Config (singleton, resolved)",
+		`├── containerinspect_test.Cache (name: "Cache") (optional, missing)`,
+		"└── *containerinspect_test.Config (singleton, resolved)",
+	}, lines(buffer.String()))
+}
+
+func TestRun_Why_With_Name(t *testing.T) {
+	c := container.New()
+	assert.NoError(t, c.NamedSingleton("memory", func() Cache { return "memory" }))
+	assert.NoError(t, c.NamedSingleton("redis", func() Cache { return "redis" }))
+
+	var buffer bytes.Buffer
+	assert.NoError(t, containerinspect.Run(&buffer, c, []string{"why", "containerinspect_test.Cache"}))
+	assert.Equal(t, []string{
+		`containerinspect_test.Cache (name: "memory") (singleton, resolved)`,
+		`containerinspect_test.Cache (name: "redis") (singleton, resolved)`,
+	}, lines(buffer.String()))
+
+	buffer.Reset()
+	assert.NoError(t, containerinspect.Run(&buffer, c, []string{"why", "-name", "redis", "containerinspect_test.Cache"}))
+	assert.Equal(t, []string{
+		`containerinspect_test.Cache (name: "redis") (singleton, resolved)`,
+	}, lines(buffer.String()))
+}
+
+func TestRun_Why_With_Missing_And_Circular_Dependencies(t *testing.T) {
+	c := container.New()
+	assert.NoError(t, c.SingletonLazy(func(db Database) Cache { return nil }))
+	assert.NoError(t, c.SingletonLazy(func(cache Cache, config *Config) Database { return nil }))
+
+	var buffer bytes.Buffer
+	assert.NoError(t, containerinspect.Run(&buffer, c, []string{"why", "containerinspect_test.Cache"}))
+	assert.Equal(t, []string{
+		"containerinspect_test.Cache (singleton, lazy)",
+		"└── containerinspect_test.Database (singleton, lazy)",
+		"    ├── containerinspect_test.Cache (circular)",
+		"    └── *containerinspect_test.Config (missing)",
+	}, lines(buffer.String()))
+}
+
+func TestRun_Why_With_Unbound_Type_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	var buffer bytes.Buffer
+	err := containerinspect.Run(&buffer, c, []string{"why", "*http.Server"})
+	assert.EqualError(t, err, "containerinspect: no binding found for: *http.Server")
+
+	err = containerinspect.Run(&buffer, c, []string{"why", "-name", "api", "*http.Server"})
+	assert.EqualError(t, err, `containerinspect: no binding found for: *http.Server (name: "api")`)
+
+	err = containerinspect.Run(&buffer, c, []string{"why"})
+	assert.EqualError(t, err, "containerinspect: why takes exactly one type")
+}
+
+func TestRun_Check(t *testing.T) {
+	c := container.New()
+	assert.NoError(t, register(c))
+
+	var buffer bytes.Buffer
+	assert.NoError(t, containerinspect.Run(&buffer, c, []string{"check"}))
+	assert.Equal(t, "no missing or circular dependencies found\n", buffer.String())
+}
+
+func TestRun_Check_With_Problems_It_Should_Fail(t *testing.T) {
+	c := container.New()
+	assert.NoError(t, c.SingletonLazy(func(db Database) Cache { return nil }))
+	assert.NoError(t, c.SingletonLazy(func(cache Cache, config *Config) Database { return nil }))
+
+	var buffer bytes.Buffer
+	err := containerinspect.Run(&buffer, c, []string{"check"})
+	assert.EqualError(t, err, "containerinspect: found 2 problem(s)")
+
+	output := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, output, 2)
+	assert.Equal(t, "container: circular dependency: containerinspect_test.Cache -> containerinspect_test.Database -> containerinspect_test.Cache", output[0])
+	assert.Contains(t, output[1], "container: no concrete found for: *containerinspect_test.Config (required by containerinspect_test.Database bound at ")
+}
+
+func TestRun_With_Unknown_Command_It_Should_Fail(t *testing.T) {
+	var buffer bytes.Buffer
+	err := containerinspect.Run(&buffer, container.New(), []string{"graph"})
+	assert.EqualError(t, err, `containerinspect: unknown command "graph", it must be bindings, why, or check`)
+}
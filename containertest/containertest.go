@@ -0,0 +1,174 @@
+// Package containertest provides helpers for testing with containers without sharing state between the tests.
+//
+// The tests clone a base container (or the global one) and override the bindings they need:
+//
+//	func TestService(t *testing.T) {
+//		t.Parallel()
+//
+//		c := containertest.Clone(base)
+//		containertest.Override(t, c, func() Database { return &FakeDatabase{} })
+//		containertest.AssertSingleton[*Service](t, c)
+//	}
+package containertest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golobby/container/v4"
+)
+
+// errorType is the reflected error type.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Clone returns a copy of c that the test can change without changing c (see container.Container.Clone).
+func Clone(c container.Container, opts ...container.CloneOption) container.Container {
+	return c.Clone(opts...)
+}
+
+// Override binds the resolver instead of the existing binding of the abstraction it returns,
+// and restores the existing binding (or deletes the new one) when the test and its subtests finish.
+// The new binding keeps the lifetime of the existing one, and it is a lazy singleton if there is no existing binding.
+// It fails the test if the resolver cannot be bound.
+func Override(t testing.TB, c container.Container, resolver interface{}) {
+	t.Helper()
+	NamedOverride(t, c, "", resolver)
+}
+
+// NamedOverride binds the resolver instead of the existing binding of the abstraction it returns with the given name,
+// and restores the existing binding (or deletes the new one) when the test and its subtests finish.
+func NamedOverride(t testing.TB, c container.Container, name string, resolver interface{}) {
+	t.Helper()
+
+	abstraction := returned(resolver)
+	if abstraction == nil {
+		t.Fatalf("containertest: cannot override: the resolver must be a function that returns an abstraction")
+		return
+	}
+
+	restore := c.SaveBinding(abstraction, name)
+	t.Cleanup(func() {
+		if err := restore(); err != nil {
+			t.Errorf("containertest: cannot restore %s: %v", label(abstraction, name), err)
+		}
+	})
+
+	if err := override(c.Override(), abstraction, name, resolver); err != nil {
+		t.Fatalf("containertest: cannot override %s: %v", label(abstraction, name), err)
+	}
+}
+
+// override binds the resolver with the lifetime and laziness of the existing binding.
+func override(c container.Container, abstraction reflect.Type, name string, resolver interface{}) error {
+	infos := c.Bindings(container.BindingsOf(abstraction), container.NamedBindings(name))
+	if len(infos) == 0 || infos[0].Lifetime == container.SingletonLifetime {
+		if len(infos) == 1 && !infos[0].IsLazy {
+			return c.NamedSingleton(name, resolver)
+		}
+		return c.NamedSingletonLazy(name, resolver)
+	}
+
+	if infos[0].IsLazy {
+		return c.NamedTransientLazy(name, resolver)
+	}
+	return c.NamedTransient(name, resolver)
+}
+
+// returned returns the abstraction that the resolver returns, or nil if it is not a resolver.
+func returned(resolver interface{}) reflect.Type {
+	t := reflect.TypeOf(resolver)
+	if t == nil || t.Kind() != reflect.Func || t.NumOut() == 0 || t.Out(0) == errorType {
+		return nil
+	}
+	return t.Out(0)
+}
+
+// label returns the abstraction type and its name (if any) for the failure messages.
+func label(abstraction reflect.Type, name string) string {
+	if name == "" {
+		return fmt.Sprint(abstraction)
+	}
+	return fmt.Sprintf("%v (name: %q)", abstraction, name)
+}
+
+// resolve resolves the named abstraction T and reports the error to the test.
+func resolve[T any](t testing.TB, c container.Container, name string) (T, bool) {
+	t.Helper()
+
+	var concrete T
+	if err := c.NamedResolve(&concrete, name); err != nil {
+		t.Errorf("containertest: %s is not resolvable: %v", label(reflect.TypeOf(&concrete).Elem(), name), err)
+		return concrete, false
+	}
+
+	return concrete, true
+}
+
+// AssertResolvable asserts that c resolves the abstraction T, and reports whether it does.
+func AssertResolvable[T any](t testing.TB, c container.Container) bool {
+	t.Helper()
+	return NamedAssertResolvable[T](t, c, "")
+}
+
+// NamedAssertResolvable asserts that c resolves the named abstraction T, and reports whether it does.
+func NamedAssertResolvable[T any](t testing.TB, c container.Container, name string) bool {
+	t.Helper()
+	_, ok := resolve[T](t, c, name)
+	return ok
+}
+
+// AssertSingleton asserts that c resolves the abstraction T to the same concrete every time, and reports whether it does.
+// The concretes are compared by their pointers if they are pointers, maps, slices, channels, or functions,
+// and by their values otherwise.
+func AssertSingleton[T any](t testing.TB, c container.Container) bool {
+	t.Helper()
+	return NamedAssertSingleton[T](t, c, "")
+}
+
+// NamedAssertSingleton asserts that c resolves the named abstraction T to the same concrete every time,
+// and reports whether it does.
+func NamedAssertSingleton[T any](t testing.TB, c container.Container, name string) bool {
+	t.Helper()
+
+	first, ok := resolve[T](t, c, name)
+	if !ok {
+		return false
+	}
+
+	second, ok := resolve[T](t, c, name)
+	if !ok {
+		return false
+	}
+
+	if !same(reflect.ValueOf(&first).Elem(), reflect.ValueOf(&second).Elem()) {
+		t.Errorf("containertest: %s is resolved to different concretes", label(reflect.TypeOf(&first).Elem(), name))
+		return false
+	}
+
+	return true
+}
+
+// same reports whether the values are the same concrete.
+func same(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		a, b = a.Elem(), b.Elem()
+		if a.Type() != b.Type() {
+			return false
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+
+	if a.Comparable() {
+		return a.Equal(b)
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
@@ -0,0 +1,119 @@
+package containertest_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/golobby/container/v4/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+type Database interface {
+	Name() string
+}
+
+type MySQL struct {
+	name string
+}
+
+func (m *MySQL) Name() string {
+	return m.name
+}
+
+// recorder is a testing.TB that records the failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func newContainer(t *testing.T) container.Container {
+	c := container.New()
+	assert.NoError(t, c.SingletonLazy(func() Database {
+		return &MySQL{name: "mysql"}
+	}))
+	assert.NoError(t, c.Transient(func() *MySQL {
+		return &MySQL{name: "transient"}
+	}))
+	return c
+}
+
+func TestClone(t *testing.T) {
+	c := newContainer(t)
+
+	clone := containertest.Clone(c)
+	assert.NoError(t, container.Unbind[Database](clone, ""))
+
+	containertest.AssertResolvable[Database](t, c)
+}
+
+func TestOverride(t *testing.T) {
+	c := newContainer(t)
+
+	t.Run("Override", func(t *testing.T) {
+		containertest.Override(t, c, func() Database {
+			return &MySQL{name: "fake"}
+		})
+		containertest.Override(t, c, func() *MySQL {
+			return &MySQL{name: "fake transient"}
+		})
+
+		var db Database
+		assert.NoError(t, c.Resolve(&db))
+		assert.Equal(t, "fake", db.Name())
+		containertest.AssertSingleton[Database](t, c)
+
+		var m1, m2 *MySQL
+		assert.NoError(t, c.Resolve(&m1))
+		assert.NoError(t, c.Resolve(&m2))
+		assert.Equal(t, "fake transient", m1.Name())
+		assert.NotSame(t, m1, m2)
+	})
+
+	var db Database
+	assert.NoError(t, c.Resolve(&db))
+	assert.Equal(t, "mysql", db.Name())
+}
+
+func TestNamedOverride_Without_Existing_Binding(t *testing.T) {
+	c := newContainer(t)
+
+	t.Run("NamedOverride", func(t *testing.T) {
+		containertest.NamedOverride(t, c, "replica", func() Database {
+			return &MySQL{name: "replica"}
+		})
+		containertest.NamedAssertSingleton[Database](t, c, "replica")
+	})
+
+	assert.False(t, c.Has(reflect.TypeOf((*Database)(nil)).Elem(), "replica"))
+}
+
+func TestAssertResolvable(t *testing.T) {
+	c := newContainer(t)
+
+	r := &recorder{TB: t}
+	assert.True(t, containertest.AssertResolvable[*MySQL](r, c))
+	assert.False(t, containertest.NamedAssertResolvable[Database](r, c, "replica"))
+	assert.Equal(t, []string{
+		"containertest: containertest_test.Database (name: \"replica\") is not resolvable: " +
+			"container: no concrete found for: containertest_test.Database",
+	}, r.errors)
+}
+
+func TestAssertSingleton(t *testing.T) {
+	c := newContainer(t)
+
+	r := &recorder{TB: t}
+	assert.True(t, containertest.AssertSingleton[Database](r, c))
+	assert.False(t, containertest.AssertSingleton[*MySQL](r, c))
+	assert.False(t, containertest.AssertSingleton[fmt.Stringer](r, c))
+	assert.Len(t, r.errors, 2)
+	assert.Equal(t, "containertest: *containertest_test.MySQL is resolved to different concretes", r.errors[0])
+}
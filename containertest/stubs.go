@@ -0,0 +1,142 @@
+package containertest
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/golobby/container/v4"
+)
+
+// Call is a recorded call of a stub.
+type Call struct {
+	Method    string        // Method is the called method, empty for function stubs.
+	Arguments []interface{} // Arguments are the call arguments.
+}
+
+// Recorder records the calls of a stub.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Record records a call of the method with the given arguments.
+// The methods of interface stubs call it and return zero values, e.g.
+//
+//	func (s *MailerStub) Send(to string) error {
+//		s.Record("Send", to)
+//		return nil
+//	}
+func (r *Recorder) Record(method string, arguments ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Arguments: arguments})
+}
+
+// Calls returns the recorded calls in the call order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// key identifies a stub by its abstraction and name.
+type key struct {
+	abstraction reflect.Type
+	name        string
+}
+
+// stub is a made stub and the recorder of its calls.
+type stub struct {
+	concrete interface{}
+	recorder *Recorder
+}
+
+// Stubs makes recording stubs for the missing bindings of containers in the test mode (see container.WithStubs).
+// Function types are stubbed with functions that record their arguments and return zero values.
+// Go cannot add methods to types at runtime, so interfaces are stubbed with the stub types registered by RegisterStub,
+// which the containerstub command generates. The interfaces without registered stubs are not stubbed.
+// Each abstraction and name gets one stub, so the test can inspect its calls after it is resolved.
+type Stubs struct {
+	mu     sync.Mutex
+	makers map[reflect.Type]func(*Recorder) interface{} // makers make the registered interface stubs.
+	stubs  map[key]stub                                 // stubs are the made stubs.
+}
+
+// NewStubs creates a Stubs without registered interface stubs.
+func NewStubs() *Stubs {
+	return &Stubs{makers: map[reflect.Type]func(*Recorder) interface{}{}, stubs: map[key]stub{}}
+}
+
+// Option returns the option that enables the test mode with the stubs, e.g. `container.New(stubs.Option())`.
+func (s *Stubs) Option() container.Option {
+	return container.WithStubs(s.Stub)
+}
+
+// Stub returns the stub of the abstraction with the given name, and makes it for the first call.
+// It returns false if the abstraction is neither a function type nor a registered interface.
+func (s *Stubs) Stub(abstraction reflect.Type, name string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key{abstraction: abstraction, name: name}
+	if made, exist := s.stubs[k]; exist {
+		return made.concrete, true
+	}
+
+	recorder := &Recorder{}
+	var concrete interface{}
+
+	if maker, exist := s.makers[abstraction]; exist {
+		concrete = maker(recorder)
+	} else if abstraction.Kind() == reflect.Func {
+		concrete = reflect.MakeFunc(abstraction, func(arguments []reflect.Value) []reflect.Value {
+			values := make([]interface{}, len(arguments))
+			for i, argument := range arguments {
+				values[i] = argument.Interface()
+			}
+			recorder.Record("", values...)
+
+			results := make([]reflect.Value, abstraction.NumOut())
+			for i := range results {
+				results[i] = reflect.Zero(abstraction.Out(i))
+			}
+			return results
+		}).Interface()
+	} else {
+		return nil, false
+	}
+
+	s.stubs[k] = stub{concrete: concrete, recorder: recorder}
+
+	return concrete, true
+}
+
+// RegisterStub registers the stub type of the interface T.
+// The maker returns a stub whose methods record their calls with the given recorder (see Recorder.Record).
+func RegisterStub[T any](s *Stubs, maker func(recorder *Recorder) T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.makers[reflect.TypeOf((*T)(nil)).Elem()] = func(recorder *Recorder) interface{} {
+		return maker(recorder)
+	}
+}
+
+// StubCalls returns the recorded calls of the stub of the abstraction T, or nil if T is not stubbed.
+func StubCalls[T any](s *Stubs) []Call {
+	return NamedStubCalls[T](s, "")
+}
+
+// NamedStubCalls returns the recorded calls of the stub of the named abstraction T, or nil if T is not stubbed.
+func NamedStubCalls[T any](s *Stubs, name string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if made, exist := s.stubs[key{abstraction: reflect.TypeOf((*T)(nil)).Elem(), name: name}]; exist {
+		return made.recorder.Calls()
+	}
+
+	return nil
+}
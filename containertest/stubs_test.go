@@ -0,0 +1,91 @@
+package containertest_test
+
+import (
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/golobby/container/v4/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+type Mailer interface {
+	Send(to string, body string) error
+}
+
+// MailerStub is the recording stub of Mailer.
+type MailerStub struct {
+	*containertest.Recorder
+}
+
+func (s MailerStub) Send(to string, body string) error {
+	s.Record("Send", to, body)
+	return nil
+}
+
+// Clock returns the current time in seconds.
+type Clock func() int64
+
+// Notifier is the service under test.
+type Notifier struct {
+	mailer Mailer
+	clock  Clock
+}
+
+func (n *Notifier) Notify(user string) error {
+	if n.clock() != 0 {
+		return nil
+	}
+	return n.mailer.Send(user, "Hello "+user)
+}
+
+func TestStubs(t *testing.T) {
+	stubs := containertest.NewStubs()
+	containertest.RegisterStub(stubs, func(recorder *containertest.Recorder) Mailer {
+		return MailerStub{Recorder: recorder}
+	})
+
+	c := container.New(stubs.Option())
+	assert.NoError(t, c.Transient(func(m Mailer, clock Clock) *Notifier {
+		return &Notifier{mailer: m, clock: clock}
+	}))
+
+	var n *Notifier
+	assert.NoError(t, c.Resolve(&n))
+	assert.NoError(t, n.Notify("alice"))
+	assert.NoError(t, c.Resolve(&n))
+	assert.NoError(t, n.Notify("bob"))
+
+	assert.Equal(t, []containertest.Call{
+		{Method: "Send", Arguments: []interface{}{"alice", "Hello alice"}},
+		{Method: "Send", Arguments: []interface{}{"bob", "Hello bob"}},
+	}, containertest.StubCalls[Mailer](stubs))
+	assert.Len(t, containertest.StubCalls[Clock](stubs), 2)
+	assert.Nil(t, containertest.NamedStubCalls[Mailer](stubs, "backup"))
+}
+
+func TestStubs_With_Fill(t *testing.T) {
+	stubs := containertest.NewStubs()
+	c := container.New(stubs.Option())
+
+	s := struct {
+		Format func(string, int) (string, error) `container:"name"`
+		Mailer Mailer                            `container:"type,optional"`
+	}{}
+	assert.NoError(t, c.Fill(&s))
+
+	result, err := s.Format("%d", 42)
+	assert.Equal(t, "", result)
+	assert.NoError(t, err)
+	assert.Nil(t, s.Mailer)
+
+	assert.Equal(t, []containertest.Call{
+		{Arguments: []interface{}{"%d", 42}},
+	}, containertest.NamedStubCalls[func(string, int) (string, error)](stubs, "Format"))
+}
+
+func TestStubs_Without_Registered_Stub(t *testing.T) {
+	c := container.New(containertest.NewStubs().Option())
+
+	var m Mailer
+	assert.EqualError(t, c.Resolve(&m), "container: no concrete found for: containertest_test.Mailer")
+}
@@ -0,0 +1,39 @@
+package container
+
+import "context"
+
+// contextKey is the key of the Container in contexts.
+type contextKey struct{}
+
+// WithContainer returns a copy of the context that holds the Container (see FromContext).
+func WithContainer(ctx context.Context, c Container) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the Container that the context holds (see WithContainer).
+func FromContext(ctx context.Context) (Container, bool) {
+	c, ok := ctx.Value(contextKey{}).(Container)
+	return c, ok
+}
+
+// containerOf returns the Container that the context holds, or the global Container if there is none.
+func containerOf(ctx context.Context) Container {
+	if c, ok := FromContext(ctx); ok {
+		return c
+	}
+	return Global
+}
+
+// ResolveFromContext resolves the abstraction T with the Container that the context holds,
+// or with the global Container if the context does not hold any.
+func ResolveFromContext[T any](ctx context.Context) (T, error) {
+	return NamedResolveFromContext[T](ctx, "")
+}
+
+// NamedResolveFromContext resolves the named abstraction T with the Container that the context holds,
+// or with the global Container if the context does not hold any.
+func NamedResolveFromContext[T any](ctx context.Context, name string) (T, error) {
+	var concrete T
+	err := containerOf(ctx).NamedResolve(&concrete, name)
+	return concrete, err
+}
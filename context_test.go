@@ -0,0 +1,67 @@
+package container_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithContainer_And_FromContext(t *testing.T) {
+	c := container.New()
+
+	ctx := container.WithContainer(context.Background(), c)
+
+	found, ok := container.FromContext(ctx)
+	assert.True(t, ok)
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+	assert.True(t, found.Has(shapeType, ""))
+
+	_, ok = container.FromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestResolveFromContext(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.NamedSingleton("rounded", func() Shape {
+		return &Circle{a: 42}
+	})
+	assert.NoError(t, err)
+
+	ctx := container.WithContainer(context.Background(), c)
+
+	s, err := container.ResolveFromContext[Shape](ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 13, s.GetArea())
+
+	s, err = container.NamedResolveFromContext[Shape](ctx, "rounded")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, s.GetArea())
+
+	_, err = container.ResolveFromContext[Database](ctx)
+	assert.EqualError(t, err, "container: no concrete found for: container_test.Database")
+}
+
+func TestResolveFromContext_Without_Container_It_Should_Use_Global(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 7}
+	})
+	assert.NoError(t, err)
+
+	s, err := container.ResolveFromContext[Shape](context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 7, s.GetArea())
+}
@@ -0,0 +1,99 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// typeOf returns the reflected type of the type parameter, including interface types.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// InstanceAs binds the abstraction T to the given value in singleton mode.
+// Unlike the `Instance` method, the value is bound to T instead of its own type, e.g. an interface it implements.
+func InstanceAs[T any](c Container, value T) error {
+	return c.instance(typeOf[T](), "", value)
+}
+
+// NamedInstanceAs binds the named abstraction T to the given value in singleton mode.
+func NamedInstanceAs[T any](c Container, name string, value T) error {
+	return c.instance(typeOf[T](), name, value)
+}
+
+// BindConfig binds the configuration structure T (or a pointer to it) in singleton mode.
+// The fields of T with `container:"config=key"` tags are filled from the config sources (see WithConfig),
+// and their keys are prefixed with the given prefix and a dot, e.g. the "db" prefix and "url" key make "db.url".
+// The structure is made at the binding time, so missing and invalid values are reported early.
+func BindConfig[T any](c Container, prefix string) error {
+	t := typeOf[T]()
+	if t.Kind() != reflect.Struct && (t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct) {
+		return errors.New("container: the config must be a structure or a pointer to a structure")
+	}
+
+	return c.bind(func() (T, error) {
+		var config T
+
+		s := reflect.ValueOf(&config).Elem()
+		if s.Kind() == reflect.Pointer {
+			s.Set(reflect.New(t.Elem()))
+			s = s.Elem()
+		}
+
+		return config, c.fill(s, prefix)
+	}, "", true, false)
+}
+
+// Unbind deletes the binding of the abstraction T with the given name.
+func Unbind[T any](c Container, name string) error {
+	return c.unbind(typeOf[T](), name)
+}
+
+// Replace binds the resolver instead of the existing binding of the abstraction T with the given name.
+// The new binding keeps the lifetime (singleton or transient) and laziness of the existing one.
+// If dispose is true, it closes the existing singleton concrete when it implements io.Closer.
+func Replace[T any](c Container, name string, resolver interface{}, dispose bool) error {
+	return c.replace(typeOf[T](), name, resolver, dispose)
+}
+
+// OnStart adds a hook that the Start method calls with the concrete of the singleton abstraction T,
+// after the components it depends on are started.
+func OnStart[T any](c Container, hook func(ctx context.Context, concrete T) error) error {
+	return NamedOnStart(c, "", hook)
+}
+
+// NamedOnStart adds a hook that the Start method calls with the concrete of the named singleton abstraction T.
+func NamedOnStart[T any](c Container, name string, hook func(ctx context.Context, concrete T) error) error {
+	return c.addHook(typeOf[T](), name, func(ctx context.Context, concrete interface{}) error {
+		t, _ := concrete.(T)
+		return hook(ctx, t)
+	}, startHook)
+}
+
+// OnStop adds a hook that the Stop method calls with the concrete of the singleton abstraction T,
+// before the components it depends on are stopped.
+func OnStop[T any](c Container, hook func(ctx context.Context, concrete T) error) error {
+	return NamedOnStop(c, "", hook)
+}
+
+// NamedOnStop adds a hook that the Stop method calls with the concrete of the named singleton abstraction T.
+func NamedOnStop[T any](c Container, name string, hook func(ctx context.Context, concrete T) error) error {
+	return c.addHook(typeOf[T](), name, func(ctx context.Context, concrete interface{}) error {
+		t, _ := concrete.(T)
+		return hook(ctx, t)
+	}, stopHook)
+}
+
+// HealthCheck adds a function that the Health method calls to check the concrete of the singleton abstraction T.
+func HealthCheck[T any](c Container, check func(ctx context.Context, concrete T) error) error {
+	return NamedHealthCheck(c, "", check)
+}
+
+// NamedHealthCheck adds a function that the Health method calls to check the concrete of the named singleton abstraction T.
+func NamedHealthCheck[T any](c Container, name string, check func(ctx context.Context, concrete T) error) error {
+	return c.addHook(typeOf[T](), name, func(ctx context.Context, concrete interface{}) error {
+		t, _ := concrete.(T)
+		return check(ctx, t)
+	}, healthCheck)
+}
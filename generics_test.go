@@ -0,0 +1,167 @@
+package container_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceAs(t *testing.T) {
+	c := container.New()
+
+	err := container.InstanceAs[Shape](c, &Circle{a: 13})
+	assert.NoError(t, err)
+
+	var s Shape
+	err = c.Resolve(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, 13, s.GetArea())
+}
+
+func TestInstanceAs_With_Nil_Value_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	err := container.InstanceAs[Shape](c, nil)
+	assert.EqualError(t, err, "container: the instance must not be nil")
+}
+
+func TestNamedInstanceAs(t *testing.T) {
+	c := container.New()
+
+	err := container.NamedInstanceAs[Shape](c, "rounded", &Circle{a: 13})
+	assert.NoError(t, err)
+
+	var s Shape
+	err = c.NamedResolve(&s, "rounded")
+	assert.NoError(t, err)
+	assert.Equal(t, 13, s.GetArea())
+}
+
+func TestUnbind(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = container.Unbind[Shape](c, "")
+	assert.NoError(t, err)
+
+	var s Shape
+	err = c.Resolve(&s)
+	assert.EqualError(t, err, "container: no concrete found for: container_test.Shape")
+
+	err = container.Unbind[Shape](c, "")
+	assert.EqualError(t, err, "container: no concrete found for: container_test.Shape")
+}
+
+type Closable struct {
+	closed bool
+	err    error
+}
+
+func (c *Closable) Close() error {
+	c.closed = true
+	return c.err
+}
+
+func TestReplace(t *testing.T) {
+	c := container.New()
+
+	err := c.TransientLazy(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	calls := 0
+	err = container.Replace[Shape](c, "", func() Shape {
+		calls++
+		return &Circle{a: 666}
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+
+	var s1, s2 Shape
+	assert.NoError(t, c.Resolve(&s1))
+	assert.NoError(t, c.Resolve(&s2))
+	assert.Equal(t, 666, s1.GetArea())
+	assert.NotSame(t, s1, s2)
+	assert.Equal(t, 2, calls)
+}
+
+func TestReplace_With_RejectDuplicates_Policy(t *testing.T) {
+	c := container.New(container.WithDuplicatePolicy(container.RejectDuplicates))
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = container.Replace[Shape](c, "", func() Shape {
+		return &Circle{a: 666}
+	}, false)
+	assert.NoError(t, err)
+}
+
+func TestReplace_With_Dispose(t *testing.T) {
+	c := container.New()
+
+	old := &Closable{}
+	err := container.InstanceAs[*Closable](c, old)
+	assert.NoError(t, err)
+
+	err = container.Replace[*Closable](c, "", func() *Closable {
+		return &Closable{}
+	}, true)
+	assert.NoError(t, err)
+	assert.True(t, old.closed)
+
+	var replaced *Closable
+	assert.NoError(t, c.Resolve(&replaced))
+	assert.NotSame(t, old, replaced)
+
+	err = container.Replace[*Closable](c, "", func() *Closable {
+		return &Closable{}
+	}, false)
+	assert.NoError(t, err)
+	assert.False(t, replaced.closed)
+}
+
+func TestReplace_With_Dispose_Error(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() *Closable {
+		return &Closable{err: errors.New("app: error")}
+	})
+	assert.NoError(t, err)
+
+	err = container.Replace[*Closable](c, "", func() *Closable {
+		return &Closable{}
+	}, true)
+	assert.EqualError(t, err, "app: error")
+}
+
+func TestReplace_With_Invalid_Resolver_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	err := container.Replace[Shape](c, "", func() Shape {
+		return &Circle{}
+	}, false)
+	assert.EqualError(t, err, "container: no concrete found for: container_test.Shape")
+
+	err = c.Singleton(func() Shape {
+		return &Circle{}
+	})
+	assert.NoError(t, err)
+
+	err = container.Replace[Shape](c, "", "STRING!", false)
+	assert.EqualError(t, err, "container: the resolver must be a function")
+
+	err = container.Replace[Shape](c, "", func() Database {
+		return &MySQL{}
+	}, false)
+	assert.EqualError(t, err, "container: the resolver must return container_test.Shape")
+}
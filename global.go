@@ -1,5 +1,10 @@
 package container
 
+import (
+	"context"
+	"reflect"
+)
+
 // Global is the global concrete of the Container.
 var Global = New()
 
@@ -43,6 +48,97 @@ func NamedTransientLazy(name string, resolver interface{}) error {
 	return Global.NamedTransientLazy(name, resolver)
 }
 
+// Instance calls the same method of the global concrete.
+func Instance(value interface{}) error {
+	return Global.Instance(value)
+}
+
+// NamedInstance calls the same method of the global concrete.
+func NamedInstance(name string, value interface{}) error {
+	return Global.NamedInstance(name, value)
+}
+
+// Factory calls the same method of the global concrete.
+func Factory(parameters int, resolver interface{}) error {
+	return Global.Factory(parameters, resolver)
+}
+
+// NamedFactory calls the same method of the global concrete.
+func NamedFactory(name string, parameters int, resolver interface{}) error {
+	return Global.NamedFactory(name, parameters, resolver)
+}
+
+// Override calls the same method of the global concrete.
+func Override() Container {
+	return Global.Override()
+}
+
+// Scope calls the same method of the global concrete.
+func Scope() Container {
+	return Global.Scope()
+}
+
+// Has calls the same method of the global concrete.
+func Has(abstraction reflect.Type, name string) bool {
+	return Global.Has(abstraction, name)
+}
+
+// Bindings calls the same method of the global concrete.
+func Bindings(filters ...BindingFilter) []BindingInfo {
+	return Global.Bindings(filters...)
+}
+
+// Build calls the same method of the global concrete.
+func Build() (Container, error) {
+	return Global.Build()
+}
+
+// Validate calls the same method of the global concrete.
+func Validate() error {
+	return Global.Validate()
+}
+
+// Start calls the same method of the global concrete.
+func Start(ctx context.Context) error {
+	return Global.Start(ctx)
+}
+
+// Stop calls the same method of the global concrete.
+func Stop(ctx context.Context) error {
+	return Global.Stop(ctx)
+}
+
+// Dispose calls the same method of the global concrete.
+func Dispose() error {
+	return Global.Dispose()
+}
+
+// Run calls the same method of the global concrete.
+func Run(ctx context.Context, opts ...RunOption) error {
+	return Global.Run(ctx, opts...)
+}
+
+// Health calls the same method of the global concrete.
+func Health(ctx context.Context) HealthReport {
+	return Global.Health(ctx)
+}
+
+// Clone calls the same method of the global concrete.
+func Clone(opts ...CloneOption) Container {
+	return Global.Clone(opts...)
+}
+
+// Restore calls the same method of the global concrete.
+// The snapshot is taken with `Global.Snapshot()`, since the Snapshot name belongs to the type.
+func Restore(snapshot Snapshot) error {
+	return Global.Restore(snapshot)
+}
+
+// Load calls the same method of the global concrete.
+func Load(manifest Manifest) error {
+	return Global.Load(manifest)
+}
+
 // Reset calls the same method of the global concrete.
 func Reset() {
 	Global.Reset()
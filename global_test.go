@@ -1,9 +1,12 @@
 package container_test
 
 import (
+	"context"
+	"reflect"
+	"runtime"
 	"testing"
 
-	"github.com/golobby/container/v3"
+	"github.com/golobby/container/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,6 +82,202 @@ func TestNamedTransientLazy(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestInstance(t *testing.T) {
+	container.Reset()
+
+	err := container.Instance(&Circle{a: 13})
+	assert.NoError(t, err)
+}
+
+func TestNamedInstance(t *testing.T) {
+	container.Reset()
+
+	err := container.NamedInstance("rounded", &Circle{a: 13})
+	assert.NoError(t, err)
+}
+
+func TestFactory(t *testing.T) {
+	container.Reset()
+
+	err := container.Factory(1, func(a int) Shape {
+		return &Circle{a: a}
+	})
+	assert.NoError(t, err)
+}
+
+func TestNamedFactory(t *testing.T) {
+	container.Reset()
+
+	err := container.NamedFactory("rounded", 1, func(a int) Shape {
+		return &Circle{a: a}
+	})
+	assert.NoError(t, err)
+}
+
+func TestOverride(t *testing.T) {
+	container.Reset()
+
+	err := container.Override().Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+}
+
+func TestScope(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	var s Shape
+	assert.NoError(t, container.Scope().Resolve(&s))
+}
+
+func TestClone(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	clone := container.Clone()
+	assert.NoError(t, clone.Override().Singleton(func() Shape {
+		return &Circle{a: 42}
+	}))
+
+	var s Shape
+	assert.NoError(t, container.Resolve(&s))
+	assert.Equal(t, 13, s.GetArea())
+}
+
+func TestRestore(t *testing.T) {
+	container.Reset()
+
+	snapshot := container.Global.Snapshot()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.Restore(snapshot))
+	assert.False(t, container.Has(reflect.TypeOf((*Shape)(nil)).Elem(), ""))
+}
+
+func TestLoad(t *testing.T) {
+	container.Reset()
+
+	err := container.Load(container.Manifest{Bindings: []container.ManifestBinding{{Factory: "test.cache.memory"}}})
+	assert.NoError(t, err)
+
+	var c Cache
+	assert.NoError(t, container.Resolve(&c))
+	assert.Equal(t, "memory", c.Name())
+}
+
+func TestHas(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, container.Has(reflect.TypeOf((*Shape)(nil)).Elem(), ""))
+}
+
+func TestBindings(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, container.Bindings(), 1)
+}
+
+func TestBuild(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	c, err := container.Build()
+	assert.NoError(t, err)
+	assert.True(t, c.IsBuilt())
+}
+
+func TestValidate(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.Validate())
+}
+
+func TestStart(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.Start(context.Background()))
+}
+
+func TestStop(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.Start(context.Background()))
+	assert.NoError(t, container.Stop(context.Background()))
+}
+
+func TestDispose(t *testing.T) {
+	container.Reset()
+
+	closable := &Closable{}
+	err := container.Instance(closable)
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.Dispose())
+	assert.True(t, closable.closed)
+}
+
+func TestRun(t *testing.T) {
+	container.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, container.Run(ctx))
+}
+
+func TestHealth(t *testing.T) {
+	container.Reset()
+
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, container.HealthUp, container.Health(context.Background()).Status)
+}
+
 func TestCall(t *testing.T) {
 	container.Reset()
 
@@ -129,3 +328,25 @@ func TestFill(t *testing.T) {
 	err = container.Fill(&myApp)
 	assert.NoError(t, err)
 }
+
+func TestSingleton_It_Should_Record_Caller_Site(t *testing.T) {
+	global := container.Global
+	defer func() { container.Global = global }()
+	container.Global = container.New(container.WithDuplicatePolicy(container.RejectDuplicates))
+
+	_, file, line, _ := runtime.Caller(0)
+	err := container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = container.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+
+	var duplicate *container.DuplicateError
+	assert.ErrorAs(t, err, &duplicate)
+	assert.Equal(t, file, duplicate.Existing.File)
+	assert.Equal(t, line+1, duplicate.Existing.Line)
+	assert.Equal(t, line+6, duplicate.Duplicate.Line)
+}
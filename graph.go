@@ -0,0 +1,165 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Dependency is an abstraction that a binding resolver depends on.
+type Dependency struct {
+	Type     reflect.Type // Type is the abstraction type.
+	Name     string       // Name is the binding name, empty for typed dependencies.
+	Optional bool         // Optional is true for the optional fields of In structures.
+}
+
+// String returns the abstraction type and the name (if any) of the dependency.
+func (d Dependency) String() string {
+	return label(d.Type, d.Name)
+}
+
+// label returns the abstraction type and the name (if any) used in errors.
+func label(abstraction reflect.Type, name string) string {
+	if name == "" {
+		return abstraction.String()
+	}
+	return fmt.Sprintf("%s (name: %q)", abstraction.String(), name)
+}
+
+// dependencies returns the dependencies of the resolver arguments, including the fields of In structures.
+func dependencies(resolver interface{}) ([]Dependency, error) {
+	if resolver == nil {
+		return nil, nil
+	}
+
+	reflectedResolver := reflect.TypeOf(resolver)
+
+	var deps []Dependency
+	for i := 0; i < reflectedResolver.NumIn(); i++ {
+		argument := reflectedResolver.In(i)
+		if !embeds(argument, inType) {
+			deps = append(deps, Dependency{Type: argument})
+			continue
+		}
+
+		tagged, err := taggedFields(argument)
+		if err != nil {
+			return deps, err
+		}
+		for _, f := range tagged {
+			if f.config == "" {
+				deps = append(deps, Dependency{Type: f.t, Name: f.name, Optional: f.optional})
+			}
+		}
+	}
+
+	return deps, nil
+}
+
+// Validate checks the bindings without building the Container.
+// It returns all the missing and circular dependencies joined, or nil if there is none.
+func (c Container) Validate() error {
+	v := &validator{container: c, states: map[*binding]int{}}
+	for _, info := range c.Bindings() {
+		v.visit(c.bindings[info.Type][info.Name], info.Type, info.Name)
+	}
+
+	return errors.Join(v.errs...)
+}
+
+// cycle returns the circular dependency error of a path from the binding back to itself, or nil if there is none.
+func (c Container) cycle(target *binding) error {
+	if target.source != nil {
+		target = target.source
+	}
+
+	visited := map[*binding]bool{}
+	var path []string
+
+	var visit func(c Container, b *binding) error
+	visit = func(c Container, b *binding) error {
+		visited[b] = true
+
+		deps, _ := dependencies(b.resolver)
+		for _, dep := range deps {
+			dependency, owner, exist := c.lookup(dep.Type, dep.Name)
+			if !exist {
+				continue
+			}
+			if dependency.source != nil {
+				dependency = dependency.source
+			}
+
+			l := label(dep.Type, dep.Name)
+			if dependency == target {
+				labels := append(append([]string{l}, path...), l)
+				return fmt.Errorf("container: circular dependency: %s", strings.Join(labels, " -> "))
+			}
+			if visited[dependency] {
+				continue
+			}
+
+			path = append(path, l)
+			if err := visit(owner, dependency); err != nil {
+				return err
+			}
+			path = path[:len(path)-1]
+		}
+
+		return nil
+	}
+
+	return visit(c, target)
+}
+
+// validator finds the missing and circular dependencies of the bindings.
+type validator struct {
+	container Container
+	states    map[*binding]int // states are zero for new, one for visiting, and two for visited bindings.
+	path      []string         // path is the chain of the abstractions being visited.
+	errs      []error
+}
+
+// visit validates the dependencies of the binding and the bindings it depends on.
+func (v *validator) visit(target *binding, abstraction reflect.Type, name string) {
+	if target.source != nil {
+		target = target.source
+	}
+
+	l := label(abstraction, name)
+
+	switch v.states[target] {
+	case 1:
+		v.errs = append(v.errs, fmt.Errorf("container: circular dependency: %s -> %s", strings.Join(v.path, " -> "), l))
+		return
+	case 2:
+		return
+	}
+
+	v.states[target] = 1
+	v.path = append(v.path, l)
+	defer func() {
+		v.path = v.path[:len(v.path)-1]
+		v.states[target] = 2
+	}()
+
+	deps, err := dependencies(target.resolver)
+	if err != nil {
+		v.errs = append(v.errs, fmt.Errorf("%w (required by %s bound at %s)", err, l, target.site))
+	}
+
+	for _, dep := range deps {
+		dependency, _, exist := v.container.lookup(dep.Type, dep.Name)
+		if !exist {
+			if !dep.Optional {
+				v.errs = append(v.errs, fmt.Errorf(
+					"container: no concrete found for: %s (required by %s bound at %s)", dep, l, target.site,
+				))
+			}
+			continue
+		}
+
+		v.visit(dependency, dep.Type, dep.Name)
+	}
+}
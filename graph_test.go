@@ -0,0 +1,119 @@
+package container_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Bindings_Dependencies(t *testing.T) {
+	c := container.New()
+
+	type Parameters struct {
+		container.In
+
+		S Shape    `container:"type"`
+		D Database `container:"name,optional"`
+	}
+
+	err := c.SingletonLazy(func(p Parameters, config *Config) *Service {
+		return &Service{s: p.S, d: p.D}
+	})
+	assert.NoError(t, err)
+
+	err = c.Instance(&Config{})
+	assert.NoError(t, err)
+
+	err = c.Factory(1, func(a int, config *Config) Shape {
+		return &Circle{a: a}
+	})
+	assert.NoError(t, err)
+
+	bindings := c.Bindings(container.BindingsOf(reflect.TypeOf(&Service{})))
+	assert.Len(t, bindings, 1)
+	assert.Equal(t, []container.Dependency{
+		{Type: shapeType},
+		{Type: databaseType, Name: "D", Optional: true},
+		{Type: reflect.TypeOf(&Config{})},
+	}, bindings[0].Dependencies)
+	assert.Equal(t, `container_test.Database (name: "D")`, bindings[0].Dependencies[1].String())
+
+	for _, info := range c.Bindings() {
+		if info.Type != reflect.TypeOf(&Service{}) {
+			assert.Empty(t, info.Dependencies)
+		}
+	}
+}
+
+func TestContainer_Validate(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.TransientLazy(func(s Shape) *Service {
+		return &Service{s: s}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Validate())
+}
+
+func TestContainer_Validate_With_Missing_And_Circular_Dependencies_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	err := c.SingletonLazy(func(d Database) Shape {
+		return &Circle{}
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func(s Shape) Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	type Parameters struct {
+		container.In
+
+		C *Config `container:"type"`
+		R Shape   `container:"name"`
+		O Shape   `container:"name,optional"`
+	}
+
+	err = c.TransientLazy(func(p Parameters) *Service {
+		return &Service{s: p.R}
+	})
+	assert.NoError(t, err)
+
+	err = c.Validate()
+	assert.Error(t, err)
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	assert.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Error(), "container: no concrete found for: *container_test.Config (required by *container_test.Service bound at ")
+	assert.Contains(t, errs[1].Error(), `container: no concrete found for: container_test.Shape (name: "R") (required by *container_test.Service bound at `)
+	assert.Equal(t, "container: circular dependency: container_test.Database -> container_test.Shape -> container_test.Database", errs[2].Error())
+}
+
+func TestContainer_Validate_With_Invalid_Struct_Tag_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	type Parameters struct {
+		container.In
+
+		S Shape `container:"invalid"`
+	}
+
+	err := c.TransientLazy(func(p Parameters) *Service {
+		return &Service{s: p.S}
+	})
+	assert.NoError(t, err)
+
+	err = c.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container: S has an invalid struct tag (required by *container_test.Service bound at ")
+}
@@ -0,0 +1,107 @@
+package container
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HealthChecker is implemented by the concretes that the Health method checks.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthStatus is the status of a health check or a health report.
+type HealthStatus string
+
+const (
+	// HealthUp is the status of the passed checks, and the reports without failed checks.
+	HealthUp HealthStatus = "up"
+	// HealthDown is the status of the failed checks, and the reports with failed checks.
+	HealthDown HealthStatus = "down"
+)
+
+// HealthResult is the result of the health checks of a binding.
+type HealthResult struct {
+	Type     string        `json:"type"`            // Type is the abstraction type.
+	Name     string        `json:"name,omitempty"`  // Name is the binding name, empty for typed bindings.
+	Status   HealthStatus  `json:"status"`          // Status is down if any check of the binding fails.
+	Error    string        `json:"error,omitempty"` // Error is the error of the failed check.
+	Duration time.Duration `json:"duration"`        // Duration is the time that the checks take, in nanoseconds.
+}
+
+// HealthReport is the report of the health checks of the bindings.
+type HealthReport struct {
+	Status  HealthStatus   `json:"status"`  // Status is down if any check fails.
+	Results []HealthResult `json:"results"` // Results are the results of the bindings sorted by their types and names.
+}
+
+// Health runs the health checks of the resolved singletons concurrently and returns the report.
+// The checks are the CheckHealth methods of the concretes (see HealthChecker) and the HealthCheck functions.
+// Each check has a timeout that the WithHealthTimeout option sets. The bindings that are not resolved yet
+// are not checked, so the report does not make them.
+func (c Container) Health(ctx context.Context) HealthReport {
+	type target struct {
+		binding *binding
+		checks  []func(ctx context.Context) error
+	}
+
+	report := HealthReport{Status: HealthUp, Results: []HealthResult{}}
+
+	var targets []target
+	for _, info := range c.Bindings(SingletonBindings, ResolvedBindings) {
+		b := c.bindings[info.Type][info.Name]
+
+		concrete, err := b.make(c)
+		if err != nil {
+			continue
+		}
+
+		var checks []func(ctx context.Context) error
+		if checker, ok := concrete.(HealthChecker); ok {
+			checks = append(checks, checker.CheckHealth)
+		}
+		for _, h := range b.healthChecks {
+			h := h
+			checks = append(checks, func(ctx context.Context) error {
+				return h(ctx, concrete)
+			})
+		}
+
+		if len(checks) > 0 {
+			targets = append(targets, target{binding: b, checks: checks})
+			report.Results = append(report.Results, HealthResult{Type: info.Type.String(), Name: info.Name})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			t := targets[i]
+			result := &report.Results[i]
+			result.Status = HealthUp
+
+			start := time.Now()
+			for _, check := range t.checks {
+				if err := c.run(ctx, c.settings().healthTimeout, check); err != nil {
+					result.Status = HealthDown
+					result.Error = err.Error()
+					break
+				}
+			}
+			result.Duration = time.Since(start)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Status == HealthDown {
+			report.Status = HealthDown
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,147 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Pinger reports the health of a remote service.
+type Pinger struct {
+	err   error
+	delay time.Duration
+}
+
+func (p *Pinger) CheckHealth(ctx context.Context) error {
+	select {
+	case <-time.After(p.delay):
+		return p.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestContainer_Health(t *testing.T) {
+	c := container.New()
+
+	assert.NoError(t, c.Instance(&Pinger{}))
+	assert.NoError(t, c.NamedInstance("queue", &Pinger{err: errors.New("queue is unreachable")}))
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = container.HealthCheck(c, func(ctx context.Context, s Shape) error {
+		if s.GetArea() != 13 {
+			return errors.New("invalid area")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func() Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	called := false
+	err = container.HealthCheck(c, func(ctx context.Context, d Database) error {
+		called = true
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() *Config {
+		return &Config{}
+	})
+	assert.NoError(t, err)
+
+	report := c.Health(context.Background())
+	assert.Equal(t, container.HealthDown, report.Status)
+	assert.False(t, called)
+	assert.Len(t, report.Results, 3)
+
+	assert.Equal(t, "*container_test.Pinger", report.Results[0].Type)
+	assert.Equal(t, "", report.Results[0].Name)
+	assert.Equal(t, container.HealthUp, report.Results[0].Status)
+	assert.Empty(t, report.Results[0].Error)
+
+	assert.Equal(t, "queue", report.Results[1].Name)
+	assert.Equal(t, container.HealthDown, report.Results[1].Status)
+	assert.Equal(t, "queue is unreachable", report.Results[1].Error)
+
+	assert.Equal(t, "container_test.Shape", report.Results[2].Type)
+	assert.Equal(t, container.HealthUp, report.Results[2].Status)
+}
+
+func TestContainer_Health_It_Should_Check_Concurrently_With_Timeouts(t *testing.T) {
+	c := container.New(container.WithHealthTimeout(50 * time.Millisecond))
+
+	assert.NoError(t, c.NamedInstance("a", &Pinger{delay: 30 * time.Millisecond}))
+	assert.NoError(t, c.NamedInstance("b", &Pinger{delay: 30 * time.Millisecond}))
+	assert.NoError(t, c.NamedInstance("c", &Pinger{delay: time.Second}))
+
+	start := time.Now()
+	report := c.Health(context.Background())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	assert.Equal(t, container.HealthDown, report.Status)
+	assert.Equal(t, container.HealthUp, report.Results[0].Status)
+	assert.Equal(t, container.HealthUp, report.Results[1].Status)
+	assert.Equal(t, container.HealthDown, report.Results[2].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Results[2].Error)
+}
+
+func TestContainer_Health_With_Many_Checks_Of_One_Binding(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	var calls []int
+	err = container.HealthCheck(c, func(ctx context.Context, s Shape) error {
+		calls = append(calls, 1)
+		return errors.New("first check failed")
+	})
+	assert.NoError(t, err)
+
+	err = container.HealthCheck(c, func(ctx context.Context, s Shape) error {
+		calls = append(calls, 2)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	report := c.Health(context.Background())
+	assert.Equal(t, container.HealthDown, report.Status)
+	assert.Equal(t, "first check failed", report.Results[0].Error)
+	assert.Equal(t, []int{1}, calls)
+}
+
+func TestContainer_Health_Without_Checks(t *testing.T) {
+	c := container.New()
+
+	report := c.Health(context.Background())
+	assert.Equal(t, container.HealthUp, report.Status)
+	assert.Empty(t, report.Results)
+}
+
+func TestContainer_HealthCheck_With_Transient_Binding_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	err := c.Transient(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = container.HealthCheck(c, func(ctx context.Context, s Shape) error {
+		return nil
+	})
+	assert.EqualError(t, err, "container: health checks need singleton bindings, container_test.Shape is transient")
+}
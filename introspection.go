@@ -0,0 +1,127 @@
+package container
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Lifetime is the lifetime of the concretes of a binding.
+type Lifetime string
+
+const (
+	// SingletonLifetime is the lifetime of singleton bindings that make the concrete once.
+	SingletonLifetime Lifetime = "singleton"
+	// TransientLifetime is the lifetime of transient bindings that make a new concrete for every resolution.
+	TransientLifetime Lifetime = "transient"
+)
+
+// BindingInfo describes a binding in the Container.
+type BindingInfo struct {
+	Type       reflect.Type // Type is the abstraction type.
+	Name       string       // Name is the binding name, empty for typed bindings.
+	Lifetime   Lifetime     // Lifetime is the binding lifetime.
+	IsLazy     bool         // IsLazy is true if the resolver is not called at the binding time.
+	IsResolved bool         // IsResolved is true if the singleton concrete is already made.
+	Resolver   string       // Resolver is the resolver function signature, empty for instance bindings.
+	Site       Site         // Site is where the binding is made.
+
+	// Dependencies are the abstractions that the resolver depends on, including the fields of In structures.
+	// Factory arguments are resolved when the factory is called, so they are not listed.
+	Dependencies []Dependency
+}
+
+// BindingFilter reports whether a binding should be listed.
+type BindingFilter func(BindingInfo) bool
+
+// info returns the information of the binding with the given abstraction and name.
+func (b *binding) info(abstraction reflect.Type, name string) BindingInfo {
+	info := BindingInfo{
+		Type:       abstraction,
+		Name:       name,
+		Lifetime:   TransientLifetime,
+		IsLazy:     b.isLazy,
+		IsResolved: b.isMade.Load() || (b.source != nil && b.source.isMade.Load()),
+		Site:       b.site,
+	}
+
+	if b.isSingleton {
+		info.Lifetime = SingletonLifetime
+	}
+
+	if b.resolver != nil {
+		info.Resolver = reflect.TypeOf(b.resolver).String()
+		info.Dependencies, _ = dependencies(b.resolver)
+	}
+
+	return info
+}
+
+// Bindings returns the information of the bindings that pass all the given filters.
+// The bindings are sorted by their types and names.
+func (c Container) Bindings(filters ...BindingFilter) []BindingInfo {
+	var infos []BindingInfo
+
+	for abstraction, named := range c.bindings {
+	next:
+		for name, b := range named {
+			info := b.info(abstraction, name)
+			for _, filter := range filters {
+				if !filter(info) {
+					continue next
+				}
+			}
+			infos = append(infos, info)
+		}
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Type.String() != infos[j].Type.String() {
+			return infos[i].Type.String() < infos[j].Type.String()
+		}
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
+
+// SingletonBindings is a BindingFilter that lists singleton bindings.
+func SingletonBindings(info BindingInfo) bool {
+	return info.Lifetime == SingletonLifetime
+}
+
+// TransientBindings is a BindingFilter that lists transient bindings.
+func TransientBindings(info BindingInfo) bool {
+	return info.Lifetime == TransientLifetime
+}
+
+// LazyBindings is a BindingFilter that lists lazy bindings.
+func LazyBindings(info BindingInfo) bool {
+	return info.IsLazy
+}
+
+// ResolvedBindings is a BindingFilter that lists the singleton bindings with made concretes.
+func ResolvedBindings(info BindingInfo) bool {
+	return info.IsResolved
+}
+
+// NamedBindings returns a BindingFilter that lists the bindings with the given name.
+func NamedBindings(name string) BindingFilter {
+	return func(info BindingInfo) bool {
+		return info.Name == name
+	}
+}
+
+// BindingsOf returns a BindingFilter that lists the bindings of the given abstraction type.
+func BindingsOf(abstraction reflect.Type) BindingFilter {
+	return func(info BindingInfo) bool {
+		return info.Type == abstraction
+	}
+}
+
+// BindingsAssignableTo returns a BindingFilter that lists the bindings whose abstractions are assignable to the given type,
+// e.g. the abstractions that implement an interface.
+func BindingsAssignableTo(t reflect.Type) BindingFilter {
+	return func(info BindingInfo) bool {
+		return info.Type.AssignableTo(t)
+	}
+}
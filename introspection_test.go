@@ -0,0 +1,101 @@
+package container_test
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	shapeType    = reflect.TypeOf((*Shape)(nil)).Elem()
+	databaseType = reflect.TypeOf((*Database)(nil)).Elem()
+)
+
+func TestContainer_Bindings(t *testing.T) {
+	c := container.New()
+
+	_, file, line, _ := runtime.Caller(0)
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.NamedTransientLazy("sql", func(s Shape) Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	err = c.NamedSingletonLazy("rounded", func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.Instance(&Config{})
+	assert.NoError(t, err)
+
+	bindings := c.Bindings()
+	assert.Len(t, bindings, 4)
+
+	assert.Equal(t, reflect.TypeOf(&Config{}), bindings[0].Type)
+	assert.Equal(t, container.SingletonLifetime, bindings[0].Lifetime)
+	assert.True(t, bindings[0].IsResolved)
+	assert.Equal(t, "", bindings[0].Resolver)
+
+	assert.Equal(t, container.BindingInfo{
+		Type:     databaseType,
+		Name:     "sql",
+		Lifetime: container.TransientLifetime,
+		IsLazy:   true,
+		Resolver: "func(container_test.Shape) container_test.Database",
+		Site:     bindings[1].Site,
+
+		Dependencies: []container.Dependency{{Type: shapeType}},
+	}, bindings[1])
+
+	assert.Equal(t, container.BindingInfo{
+		Type:       shapeType,
+		Name:       "",
+		Lifetime:   container.SingletonLifetime,
+		IsResolved: true,
+		Resolver:   "func() container_test.Shape",
+		Site: container.Site{
+			File:     file,
+			Line:     line + 1,
+			Function: "github.com/golobby/container/v4_test.TestContainer_Bindings",
+		},
+	}, bindings[2])
+
+	assert.Equal(t, "rounded", bindings[3].Name)
+	assert.False(t, bindings[3].IsResolved)
+
+	var s Shape
+	assert.NoError(t, c.NamedResolve(&s, "rounded"))
+	assert.True(t, c.Bindings(container.NamedBindings("rounded"))[0].IsResolved)
+}
+
+func TestContainer_Bindings_With_Filters(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() (Shape, Database) {
+		return &Circle{a: 13}, &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	err = c.NamedTransientLazy("sql", func() Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, c.Bindings(container.SingletonBindings), 2)
+	assert.Len(t, c.Bindings(container.TransientBindings), 1)
+	assert.Len(t, c.Bindings(container.LazyBindings), 1)
+	assert.Len(t, c.Bindings(container.ResolvedBindings), 2)
+	assert.Len(t, c.Bindings(container.NamedBindings("sql")), 1)
+	assert.Len(t, c.Bindings(container.BindingsOf(databaseType)), 2)
+	assert.Len(t, c.Bindings(container.BindingsOf(databaseType), container.SingletonBindings), 1)
+	assert.Len(t, c.Bindings(container.BindingsAssignableTo(reflect.TypeOf((*interface{ GetArea() int })(nil)).Elem())), 1)
+	assert.Len(t, container.New().Bindings(), 0)
+}
@@ -0,0 +1,321 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Starter is implemented by the components that the Start method of the Container starts.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is implemented by the components that the Stop method of the Container stops.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+var (
+	starterType = reflect.TypeOf((*Starter)(nil)).Elem()
+	stopperType = reflect.TypeOf((*Stopper)(nil)).Elem()
+)
+
+// hook is a lifecycle hook that receives the concrete of its binding.
+type hook func(ctx context.Context, concrete interface{}) error
+
+// component is a started singleton.
+type component struct {
+	binding  *binding    // binding is the singleton binding.
+	label    string      // label is the abstraction type and name used in errors.
+	concrete interface{} // concrete is the singleton concrete.
+	stopper  Stopper     // stopper is the concrete if it implements Stopper and is not stopped by another component.
+}
+
+// lifecycle holds the started components of a Container.
+type lifecycle struct {
+	mu      sync.Mutex
+	started []*component      // started are the started components in the start order.
+	bound   map[*binding]bool // bound are the bindings of the started components.
+}
+
+// hookKind is the kind of the hooks of a binding.
+type hookKind int
+
+const (
+	startHook   hookKind = iota // startHook is the kind of the OnStart hooks.
+	stopHook                    // stopHook is the kind of the OnStop hooks.
+	healthCheck                 // healthCheck is the kind of the HealthCheck functions.
+)
+
+// addHook adds the hook of the given kind to the singleton binding of the abstraction and name.
+func (c Container) addHook(abstraction reflect.Type, name string, h hook, kind hookKind) error {
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	b, exist := c.bindings[abstraction][name]
+	if !exist {
+		return fmt.Errorf("container: no concrete found for: %s", label(abstraction, name))
+	}
+	if !b.isSingleton {
+		hooks := "lifecycle hooks"
+		if kind == healthCheck {
+			hooks = "health checks"
+		}
+		return fmt.Errorf("container: %s need singleton bindings, %s is transient", hooks, label(abstraction, name))
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch kind {
+	case startHook:
+		b.onStart = append(b.onStart, h)
+	case stopHook:
+		b.onStop = append(b.onStop, h)
+	case healthCheck:
+		b.healthChecks = append(b.healthChecks, h)
+	}
+
+	return nil
+}
+
+// order returns the bindings sorted so that the dependencies come before the bindings that depend on them.
+func (c Container) order() []BindingInfo {
+	infos := c.Bindings()
+
+	bindings := make(map[Dependency]BindingInfo, len(infos))
+	for _, info := range infos {
+		bindings[Dependency{Type: info.Type, Name: info.Name}] = info
+	}
+
+	var ordered []BindingInfo
+	visited := map[Dependency]bool{}
+
+	var visit func(info BindingInfo)
+	visit = func(info BindingInfo) {
+		key := Dependency{Type: info.Type, Name: info.Name}
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+
+		for _, dep := range info.Dependencies {
+			if dependency, exist := bindings[Dependency{Type: dep.Type, Name: dep.Name}]; exist {
+				visit(dependency)
+			}
+		}
+		ordered = append(ordered, info)
+	}
+
+	for _, info := range infos {
+		visit(info)
+	}
+
+	return ordered
+}
+
+// isComponent checks if the singleton binding has lifecycle hooks or its abstraction or concrete
+// implements Starter or Stopper.
+func (b *binding) isComponent(abstraction reflect.Type) bool {
+	if len(b.onStart) > 0 || len(b.onStop) > 0 {
+		return true
+	}
+
+	if abstraction.Implements(starterType) || abstraction.Implements(stopperType) {
+		return true
+	}
+
+	if b.isMade.Load() {
+		switch b.concrete.(type) {
+		case Starter, Stopper:
+			return true
+		}
+	}
+
+	return false
+}
+
+// startComponents starts the singletons in the dependency order, each with the hook timeout.
+// A component is started by its Start method (see Starter), then by its OnStart hooks.
+// The lazy singletons are made first if they have hooks or their abstractions implement Starter or Stopper.
+// If a component fails to start, the components started before it are stopped in the reverse order.
+func (c Container) startComponents(ctx context.Context) error {
+	if c.lifecycle == nil {
+		return nil
+	}
+
+	c.lifecycle.mu.Lock()
+	defer c.lifecycle.mu.Unlock()
+
+	if c.lifecycle.bound == nil {
+		c.lifecycle.bound = map[*binding]bool{}
+	}
+
+	// concretes holds the started concretes, so the ones bound to many abstractions are started only once.
+	concretes := map[interface{}]bool{}
+	for _, started := range c.lifecycle.started {
+		if isComparable(started.concrete) {
+			concretes[started.concrete] = true
+		}
+	}
+
+	for _, info := range c.order() {
+		b := c.bindings[info.Type][info.Name]
+		if !b.isSingleton || c.lifecycle.bound[b] || !b.isComponent(info.Type) {
+			continue
+		}
+
+		cmp := &component{binding: b, label: label(info.Type, info.Name)}
+
+		concrete, err := b.make(c)
+		cmp.concrete = concrete
+
+		duplicate := isComparable(concrete) && concretes[concrete]
+		if starter, ok := concrete.(Starter); ok && !duplicate && err == nil {
+			err = c.run(ctx, c.settings().hookTimeout, starter.Start)
+		}
+
+		for _, h := range b.onStart {
+			if err != nil {
+				break
+			}
+			h := h
+			err = c.run(ctx, c.settings().hookTimeout, func(ctx context.Context) error {
+				return h(ctx, concrete)
+			})
+		}
+
+		if err != nil {
+			err = fmt.Errorf("container: cannot start %s (bound at %s): %w", cmp.label, b.site, err)
+			return errors.Join(append([]error{err}, c.stopComponents(context.WithoutCancel(ctx))...)...)
+		}
+
+		if stopper, ok := concrete.(Stopper); ok && !duplicate {
+			cmp.stopper = stopper
+		}
+		if isComparable(concrete) {
+			concretes[concrete] = true
+		}
+
+		c.lifecycle.started = append(c.lifecycle.started, cmp)
+		c.lifecycle.bound[b] = true
+	}
+
+	return nil
+}
+
+// Stop stops the started components in the reverse order of starting, each with the hook timeout.
+// A component is stopped by its OnStop hooks, then by its Stop method (see Stopper).
+// It stops all the components even if some of them fail, and returns the errors joined.
+func (c Container) Stop(ctx context.Context) error {
+	if c.lifecycle == nil {
+		return nil
+	}
+
+	c.lifecycle.mu.Lock()
+	defer c.lifecycle.mu.Unlock()
+
+	return errors.Join(c.stopComponents(ctx)...)
+}
+
+// stopComponents stops the started components in the reverse order and forgets them.
+func (c Container) stopComponents(ctx context.Context) []error {
+	var errs []error
+	for i := len(c.lifecycle.started) - 1; i >= 0; i-- {
+		cmp := c.lifecycle.started[i]
+
+		var stops []func(ctx context.Context) error
+		for _, h := range cmp.binding.onStop {
+			h := h
+			stops = append(stops, func(ctx context.Context) error {
+				return h(ctx, cmp.concrete)
+			})
+		}
+		if cmp.stopper != nil {
+			stops = append(stops, cmp.stopper.Stop)
+		}
+
+		for _, stop := range stops {
+			if err := c.run(ctx, c.settings().hookTimeout, stop); err != nil {
+				errs = append(errs, fmt.Errorf("container: cannot stop %s (bound at %s): %w", cmp.label, cmp.binding.site, err))
+			}
+		}
+	}
+
+	c.lifecycle.started = nil
+	c.lifecycle.bound = nil
+
+	return errs
+}
+
+// run calls the function with a context that is canceled after the timeout.
+// It returns the context error when the timeout is reached, even if the function does not return.
+func (c Container) run(ctx context.Context, timeout time.Duration, function func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- function(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		select {
+		case err := <-done:
+			return err
+		default:
+			return ctx.Err()
+		}
+	}
+}
+
+// isComparable checks if the concrete can be a map key.
+func isComparable(concrete interface{}) bool {
+	return concrete != nil && reflect.TypeOf(concrete).Comparable()
+}
+
+// Dispose closes the made singletons that implement io.Closer in the reverse dependency order,
+// so the concretes are closed before the ones they depend on.
+// The concretes bound to many abstractions are closed only once.
+// It closes all the concretes even if some of them fail, and returns the errors joined.
+func (c Container) Dispose() error {
+	ordered := c.order()
+	closed := map[interface{}]bool{}
+
+	var errs []error
+	for i := len(ordered) - 1; i >= 0; i-- {
+		info := ordered[i]
+		b := c.bindings[info.Type][info.Name]
+		if !b.isSingleton || !info.IsResolved {
+			continue
+		}
+
+		concrete, err := b.make(c)
+		if err != nil {
+			continue
+		}
+
+		closer, ok := concrete.(io.Closer)
+		if !ok || (isComparable(concrete) && closed[concrete]) {
+			continue
+		}
+		if isComparable(concrete) {
+			closed[concrete] = true
+		}
+
+		if err = closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("container: cannot dispose %s (bound at %s): %w", label(info.Type, info.Name), b.site, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
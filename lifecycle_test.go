@@ -0,0 +1,309 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Component records its lifecycle events.
+type Component struct {
+	name     string
+	events   *[]string
+	startErr error
+	stopErr  error
+}
+
+func (c *Component) Start(ctx context.Context) error {
+	*c.events = append(*c.events, "start "+c.name)
+	return c.startErr
+}
+
+func (c *Component) Stop(ctx context.Context) error {
+	*c.events = append(*c.events, "stop "+c.name)
+	return c.stopErr
+}
+
+func (c *Component) Close() error {
+	*c.events = append(*c.events, "close "+c.name)
+	return nil
+}
+
+type Server struct{ *Component }
+
+type Consumer struct{ *Component }
+
+func TestContainer_Start_And_Stop_Components(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.Singleton(func() *Component {
+		return &Component{name: "database", events: &events}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(db *Component) *Server {
+		return &Server{&Component{name: "server", events: &events}}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(s *Server) *Consumer {
+		return &Consumer{&Component{name: "consumer", events: &events}}
+	})
+	assert.NoError(t, err)
+
+	err = c.Transient(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Equal(t, []string{"start database", "start server", "start consumer"}, events)
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Len(t, events, 3)
+
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Equal(t, []string{
+		"start database", "start server", "start consumer",
+		"stop consumer", "stop server", "stop database",
+	}, events)
+
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Len(t, events, 6)
+}
+
+func TestContainer_Start_With_Hooks(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.SingletonLazy(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(s Shape) Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.OnStart(c, func(ctx context.Context, d Database) error {
+		events = append(events, "start database")
+		return nil
+	}))
+	assert.NoError(t, container.OnStart(c, func(ctx context.Context, s Shape) error {
+		events = append(events, "start shape")
+		assert.Equal(t, 13, s.GetArea())
+		return nil
+	}))
+	assert.NoError(t, container.OnStop(c, func(ctx context.Context, s Shape) error {
+		events = append(events, "stop shape")
+		return nil
+	}))
+	assert.NoError(t, container.OnStop(c, func(ctx context.Context, d Database) error {
+		events = append(events, "stop database")
+		return nil
+	}))
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Equal(t, []string{"start shape", "start database", "stop database", "stop shape"}, events)
+}
+
+func TestContainer_Start_With_Many_Hooks_Of_One_Binding(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	var calls []string
+	for _, name := range []string{"1", "2"} {
+		name := name
+		assert.NoError(t, container.OnStart(c, func(ctx context.Context, s Shape) error {
+			calls = append(calls, "start "+name)
+			return nil
+		}))
+		assert.NoError(t, container.OnStop(c, func(ctx context.Context, s Shape) error {
+			calls = append(calls, "stop "+name)
+			return nil
+		}))
+	}
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Equal(t, []string{"start 1", "start 2", "stop 1", "stop 2"}, calls)
+}
+
+func TestContainer_Start_With_Lazy_Component(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.SingletonLazy(func() *Server {
+		return &Server{&Component{name: "server", events: &events}}
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func() *Circle {
+		events = append(events, "make circle")
+		return &Circle{}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Equal(t, []string{"start server"}, events)
+}
+
+func TestContainer_Start_With_Concrete_Bound_Twice_It_Should_Start_It_Once(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	component := &Component{name: "database", events: &events}
+
+	assert.NoError(t, c.Instance(component))
+	assert.NoError(t, container.InstanceAs[container.Starter](c, component))
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Equal(t, []string{"start database", "stop database"}, events)
+}
+
+func TestContainer_Start_With_Failed_Component_It_Should_Roll_Back(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.Singleton(func() *Component {
+		return &Component{name: "database", events: &events}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(db *Component) *Server {
+		return &Server{&Component{name: "server", events: &events, startErr: errors.New("port in use")}}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(s *Server) *Consumer {
+		return &Consumer{&Component{name: "consumer", events: &events}}
+	})
+	assert.NoError(t, err)
+
+	err = c.Start(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container: cannot start *container_test.Server (bound at ")
+	assert.Contains(t, err.Error(), "port in use")
+	assert.Equal(t, []string{"start database", "start server", "stop database"}, events)
+
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Len(t, events, 3)
+}
+
+func TestContainer_Stop_With_Failed_Components_It_Should_Stop_All(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.Singleton(func() *Component {
+		return &Component{name: "database", events: &events, stopErr: errors.New("database error")}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(db *Component) *Server {
+		return &Server{&Component{name: "server", events: &events, stopErr: errors.New("server error")}}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Start(context.Background()))
+
+	err = c.Stop(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"start database", "start server", "stop server", "stop database"}, events)
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "container: cannot stop *container_test.Server (bound at ")
+	assert.Contains(t, errs[0].Error(), "server error")
+	assert.Contains(t, errs[1].Error(), "database error")
+}
+
+func TestContainer_Start_With_Hook_Timeout_It_Should_Fail(t *testing.T) {
+	c := container.New(container.WithHookTimeout(10 * time.Millisecond))
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.OnStart(c, func(ctx context.Context, s Shape) error {
+		time.Sleep(time.Second)
+		return nil
+	}))
+
+	start := time.Now()
+	err = c.Start(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestContainer_OnStart_With_Invalid_Bindings_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	hook := func(ctx context.Context, s Shape) error {
+		return nil
+	}
+
+	err := container.OnStart(c, hook)
+	assert.EqualError(t, err, "container: no concrete found for: container_test.Shape")
+
+	err = c.Transient(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = container.OnStart(c, hook)
+	assert.EqualError(t, err, "container: lifecycle hooks need singleton bindings, container_test.Shape is transient")
+
+	err = container.NamedOnStop(c, "rounded", hook)
+	assert.EqualError(t, err, `container: no concrete found for: container_test.Shape (name: "rounded")`)
+
+	built, err := c.Build()
+	assert.NoError(t, err)
+	assert.EqualError(t, container.OnStart(built, hook), "container: the container is built and its bindings cannot be changed")
+}
+
+func TestContainer_Dispose(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.Singleton(func() *Component {
+		return &Component{name: "database", events: &events}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(db *Component) *Server {
+		return &Server{&Component{name: "server", events: &events}}
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func() *Consumer {
+		return &Consumer{&Component{name: "consumer", events: &events}}
+	})
+	assert.NoError(t, err)
+
+	closable := &Closable{err: errors.New("closable error")}
+	assert.NoError(t, c.Instance(closable))
+	assert.NoError(t, container.NamedInstanceAs(c, "again", closable))
+
+	err = c.Dispose()
+	assert.Error(t, err)
+	assert.Equal(t, []string{"close server", "close database"}, events)
+	assert.True(t, closable.closed)
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "container: cannot dispose *container_test.Closable")
+	assert.Contains(t, errs[0].Error(), "closable error")
+}
@@ -0,0 +1,369 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// Params are the parameters of a manifest binding, keyed like the config values (see ConfigSource).
+// The factory resolvers take them as an argument, e.g. `func(p container.Params, log Logger) (Cache, error)`.
+type Params map[string]string
+
+// Lookup returns the value of the parameter, so the Params are a ConfigSource.
+func (p Params) Lookup(key string) (string, bool) {
+	value, exist := p[key]
+	return value, exist
+}
+
+// Fill fills the fields of the structure with `container:"config=key"` tags from the parameters.
+func (p Params) Fill(structure interface{}) error {
+	return New(WithConfig(p)).Fill(structure)
+}
+
+// paramsType is the reflected Params type.
+var paramsType = reflect.TypeOf(Params{})
+
+// factories are the resolvers registered by RegisterFactory.
+var factories = struct {
+	sync.RWMutex
+	resolvers map[string]interface{}
+}{resolvers: map[string]interface{}{}}
+
+// RegisterFactory registers the resolver with the key that manifests refer to, like "cache.redis" (see Load).
+// The resolver returns one abstraction and optionally an error, like the resolvers of the Singleton method,
+// and it can take the Params of the manifest binding besides the abstractions bound in the Container.
+// It is meant to be called in init functions, and it fails if the key is already registered.
+func RegisterFactory(key string, resolver interface{}) error {
+	reflectedResolver := reflect.TypeOf(resolver)
+	if reflectedResolver == nil || reflectedResolver.Kind() != reflect.Func {
+		return errors.New("container: the resolver must be a function")
+	}
+
+	if err := validateResolverFunction(reflectedResolver); err != nil {
+		return err
+	}
+
+	if len(returnedAbstractions(reflectedResolver)) != 1 || embeds(reflectedResolver.Out(0), outType) {
+		return errors.New("container: the factory resolver must return one abstraction, and optionally an error")
+	}
+
+	factories.Lock()
+	defer factories.Unlock()
+
+	if _, exist := factories.resolvers[key]; exist {
+		return fmt.Errorf("container: the factory %q is already registered", key)
+	}
+	factories.resolvers[key] = resolver
+
+	return nil
+}
+
+// Manifest declares bindings of registered factories, so the implementations are switched without recompiling.
+// For example, the YAML manifest below (see ParseManifest) binds the "cache.redis" factory as the "sessions" singleton:
+//
+//	bindings:
+//	  - factory: cache.redis
+//	    type: cache.Cache
+//	    name: sessions
+//	    lifetime: singleton
+//	    params:
+//	      address: localhost:6379
+type Manifest struct {
+	Bindings []ManifestBinding `json:"bindings"`
+}
+
+// ManifestBinding declares the binding of a registered factory.
+type ManifestBinding struct {
+	Factory  string                 `json:"factory"`  // Factory is the registered factory key.
+	Type     string                 `json:"type"`     // Type is the abstraction type that the factory must return, if it is not empty.
+	Name     string                 `json:"name"`     // Name is the binding name, empty for typed bindings.
+	Lifetime Lifetime               `json:"lifetime"` // Lifetime is the binding lifetime, singleton if it is empty.
+	Lazy     bool                   `json:"lazy"`     // Lazy is true if the resolver is not called at the binding time.
+	Params   map[string]interface{} `json:"params"`   // Params are passed to the factory as Params, with dotted nested keys.
+}
+
+// ParseManifest parses the manifest and rejects unknown fields.
+// It parses JSON if the unmarshal function is nil, and other formats with their parsers, like yaml.Unmarshal.
+// The unmarshal function must parse the document into the given *interface{}.
+// The numbers of the params are kept as json.Number values, whatever the format is.
+func ParseManifest(data []byte, unmarshal func(data []byte, v interface{}) error) (Manifest, error) {
+	if unmarshal != nil {
+		var document interface{}
+		if err := unmarshal(data, &document); err != nil {
+			return Manifest{}, fmt.Errorf("container: cannot parse the manifest: %w", err)
+		}
+
+		var err error
+		if data, err = json.Marshal(jsonDocument(document)); err != nil {
+			return Manifest{}, fmt.Errorf("container: cannot parse the manifest: %w", err)
+		}
+	}
+
+	var manifest Manifest
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	decoder.UseNumber()
+	if err := decoder.Decode(&manifest); err != nil {
+		return Manifest{}, fmt.Errorf("container: cannot parse the manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// jsonDocument returns the document with string map keys, so it can be encoded as JSON.
+func jsonDocument(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(n))
+		for k, v := range n {
+			m[fmt.Sprint(k)] = jsonDocument(v)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(n))
+		for k, v := range n {
+			m[k] = jsonDocument(v)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(n))
+		for i, v := range n {
+			s[i] = jsonDocument(v)
+		}
+		return s
+	default:
+		return n
+	}
+}
+
+// ReadManifest reads and parses the manifest file (see ParseManifest).
+func ReadManifest(path string, unmarshal func(data []byte, v interface{}) error) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("container: cannot read the manifest: %w", err)
+	}
+	return ParseManifest(data, unmarshal)
+}
+
+// entry is a validated manifest binding.
+type entry struct {
+	ManifestBinding
+	index       int          // index is the position of the binding in the manifest.
+	abstraction reflect.Type // abstraction is the type that the factory returns.
+	resolver    interface{}  // resolver is the factory resolver with the Params injected.
+}
+
+// label returns the position and the factory of the manifest binding for the errors.
+func (e entry) label() string {
+	return fmt.Sprintf("manifest binding %d (%s)", e.index, e.Factory)
+}
+
+// Load binds the factories that the manifest declares (see RegisterFactory).
+// The whole manifest is validated before anything is bound: the factories must be registered,
+// the lifetimes and types must match, and the Container with the manifest bindings must be valid (see Validate).
+// The bindings are made in their dependency order. If any resolver fails, the bindings of the manifest are removed
+// and the ones that they replaced are put back, so the other bindings are not changed.
+func (c Container) Load(manifest Manifest) error {
+	if err := c.checkMutable(); err != nil {
+		return err
+	}
+
+	entries, err := c.entries(manifest)
+	if err != nil {
+		return err
+	}
+
+	replaced := make([]*binding, len(entries))
+	for i, e := range entries {
+		replaced[i] = c.bindings[e.abstraction][e.Name]
+
+		isSingleton, isLazy := e.Lifetime != TransientLifetime, e.Lazy
+		if err := c.bind(e.resolver, e.Name, isSingleton, isLazy); err != nil {
+			c.unload(entries[:i+1], replaced)
+			return fmt.Errorf("container: cannot bind %s: %w", e.label(), err)
+		}
+	}
+
+	return nil
+}
+
+// unload undoes the bindings of the entries in the reverse order.
+// It puts back the bindings that they replaced, and deletes the ones that replaced nothing.
+func (c Container) unload(entries []entry, replaced []*binding) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if replaced[i] != nil {
+			c.bindings[e.abstraction][e.Name] = replaced[i]
+			continue
+		}
+
+		delete(c.bindings[e.abstraction], e.Name)
+		if len(c.bindings[e.abstraction]) == 0 {
+			delete(c.bindings, e.abstraction)
+		}
+	}
+}
+
+// entries validates the manifest bindings and returns them in their dependency order.
+func (c Container) entries(manifest Manifest) ([]entry, error) {
+	var errs []error
+	var entries []entry
+
+	keys := map[string]bool{}
+	for i, b := range manifest.Bindings {
+		e, err := newEntry(i, b)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		key := label(e.abstraction, e.Name)
+		if keys[key] {
+			errs = append(errs, fmt.Errorf("container: %s binds %s again", e.label(), key))
+			continue
+		}
+		keys[key] = true
+
+		if _, exist := c.bindings[e.abstraction][e.Name]; exist && c.settings().duplicatePolicy == RejectDuplicates {
+			errs = append(errs, fmt.Errorf("container: %s binds %s that is already bound", e.label(), key))
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	clone := c.Clone().Override()
+	for _, e := range entries {
+		if err := clone.bind(e.resolver, e.Name, e.Lifetime != TransientLifetime, true); err != nil {
+			errs = append(errs, fmt.Errorf("container: cannot bind %s: %w", e.label(), err))
+		}
+	}
+
+	if err := clone.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return sortEntries(entries), nil
+}
+
+// newEntry validates the manifest binding and makes its resolver.
+func newEntry(index int, b ManifestBinding) (entry, error) {
+	e := entry{ManifestBinding: b, index: index}
+
+	factories.RLock()
+	resolver, exist := factories.resolvers[b.Factory]
+	factories.RUnlock()
+
+	if !exist {
+		return e, fmt.Errorf("container: %s has no registered factory", e.label())
+	}
+
+	if b.Lifetime != "" && b.Lifetime != SingletonLifetime && b.Lifetime != TransientLifetime {
+		return e, fmt.Errorf("container: %s has an invalid lifetime %q - it must be singleton or transient", e.label(), b.Lifetime)
+	}
+
+	reflectedResolver := reflect.TypeOf(resolver)
+	e.abstraction = reflectedResolver.Out(0)
+	if b.Type != "" && b.Type != e.abstraction.String() {
+		return e, fmt.Errorf("container: %s returns %s, not %s", e.label(), e.abstraction, b.Type)
+	}
+
+	params := Params{}
+	flatten(params, "", b.Params)
+	delete(params, "")
+
+	e.resolver = withParams(resolver, params)
+	if e.resolver == nil {
+		return e, fmt.Errorf("container: %s has params, but the factory does not take them", e.label())
+	}
+
+	return e, nil
+}
+
+// withParams returns a resolver that calls the given one with the params in its Params arguments,
+// and takes its other arguments only. It returns nil if there are params but no Params arguments.
+func withParams(resolver interface{}, params Params) interface{} {
+	reflectedResolver := reflect.TypeOf(resolver)
+
+	var in []reflect.Type
+	for i := 0; i < reflectedResolver.NumIn(); i++ {
+		if reflectedResolver.In(i) != paramsType {
+			in = append(in, reflectedResolver.In(i))
+		}
+	}
+
+	if len(in) == reflectedResolver.NumIn() {
+		if len(params) > 0 {
+			return nil
+		}
+		return resolver
+	}
+
+	out := make([]reflect.Type, reflectedResolver.NumOut())
+	for i := range out {
+		out[i] = reflectedResolver.Out(i)
+	}
+
+	function := reflect.ValueOf(resolver)
+	return reflect.MakeFunc(reflect.FuncOf(in, out, false), func(args []reflect.Value) []reflect.Value {
+		arguments := make([]reflect.Value, 0, reflectedResolver.NumIn())
+		for i := 0; i < reflectedResolver.NumIn(); i++ {
+			if reflectedResolver.In(i) == paramsType {
+				arguments = append(arguments, reflect.ValueOf(params))
+			} else {
+				arguments = append(arguments, args[0])
+				args = args[1:]
+			}
+		}
+		return function.Call(arguments)
+	}).Interface()
+}
+
+// sortEntries returns the entries in their dependency order, so the eager ones are made after their dependencies.
+// The entries are validated, so they have no circular dependencies.
+func sortEntries(entries []entry) []entry {
+	positions := map[string]int{}
+	for i, e := range entries {
+		positions[label(e.abstraction, e.Name)] = i
+	}
+
+	sorted := make([]entry, 0, len(entries))
+	visited := make([]bool, len(entries))
+
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+
+		deps, _ := dependencies(entries[i].resolver)
+		for _, dep := range deps {
+			if j, exist := positions[label(dep.Type, dep.Name)]; exist {
+				visit(j)
+			}
+		}
+
+		sorted = append(sorted, entries[i])
+	}
+
+	for i := range entries {
+		visit(i)
+	}
+
+	return sorted
+}
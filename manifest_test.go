@@ -0,0 +1,252 @@
+package container_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Cache interface {
+	Name() string
+}
+
+type RedisCache struct {
+	Address string `container:"config=address"`
+	Pool    int    `container:"config=pool.size,default=10"`
+}
+
+func (r *RedisCache) Name() string {
+	return "redis"
+}
+
+type MemoryCache struct{}
+
+func (m MemoryCache) Name() string {
+	return "memory"
+}
+
+type CacheService struct {
+	cache Cache
+}
+
+func init() {
+	factories := map[string]interface{}{
+		"test.cache.redis": func(p container.Params) (Cache, error) {
+			r := &RedisCache{}
+			return r, p.Fill(r)
+		},
+		"test.cache.memory": func() Cache {
+			return MemoryCache{}
+		},
+		"test.service": func(c Cache) *CacheService {
+			return &CacheService{cache: c}
+		},
+		"test.failing": func() (Shape, error) {
+			return nil, errors.New("app: unavailable")
+		},
+	}
+	for key, resolver := range factories {
+		if err := container.RegisterFactory(key, resolver); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestRegisterFactory(t *testing.T) {
+	err := container.RegisterFactory("test.cache.memory", func() Cache {
+		return MemoryCache{}
+	})
+	assert.EqualError(t, err, `container: the factory "test.cache.memory" is already registered`)
+
+	err = container.RegisterFactory("test.invalid", "resolver")
+	assert.EqualError(t, err, "container: the resolver must be a function")
+
+	err = container.RegisterFactory("test.invalid", func() {})
+	assert.Error(t, err)
+
+	err = container.RegisterFactory("test.invalid", func() (Shape, Database) {
+		return nil, nil
+	})
+	assert.EqualError(t, err, "container: the factory resolver must return one abstraction, and optionally an error")
+}
+
+func TestParseManifest(t *testing.T) {
+	data := []byte(`{"bindings": [{"factory": "test.cache.redis", "type": "container_test.Cache", "name": "sessions",
+		"lifetime": "transient", "lazy": true, "params": {"address": "localhost:6379", "pool": {"size": 5}}}]}`)
+
+	manifest, err := container.ParseManifest(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, container.Manifest{Bindings: []container.ManifestBinding{{
+		Factory:  "test.cache.redis",
+		Type:     "container_test.Cache",
+		Name:     "sessions",
+		Lifetime: container.TransientLifetime,
+		Lazy:     true,
+		Params:   map[string]interface{}{"address": "localhost:6379", "pool": map[string]interface{}{"size": json.Number("5")}},
+	}}}, manifest)
+
+	_, err = container.ParseManifest([]byte(`{"bindings": [{"factory": "test.cache.redis", "kind": "cache"}]}`), nil)
+	assert.EqualError(t, err, `container: cannot parse the manifest: json: unknown field "kind"`)
+}
+
+func TestParseManifest_With_Unmarshal(t *testing.T) {
+	// unmarshal parses the documents like the YAML parsers, with int numbers and non-string map keys.
+	unmarshal := func(data []byte, v interface{}) error {
+		switch string(data) {
+		case "manifest":
+			*v.(*interface{}) = map[interface{}]interface{}{"bindings": []interface{}{map[interface{}]interface{}{
+				"factory": "test.cache.redis",
+				"params":  map[interface{}]interface{}{"address": "localhost:6379", "pool": map[interface{}]interface{}{"size": 5}},
+			}}}
+		case "unknown":
+			*v.(*interface{}) = map[string]interface{}{"bindings": []interface{}{map[string]interface{}{"kind": "cache"}}}
+		default:
+			return errors.New("invalid document")
+		}
+		return nil
+	}
+
+	manifest, err := container.ParseManifest([]byte("manifest"), unmarshal)
+	assert.NoError(t, err)
+	assert.Equal(t, container.Manifest{Bindings: []container.ManifestBinding{{
+		Factory: "test.cache.redis",
+		Params:  map[string]interface{}{"address": "localhost:6379", "pool": map[string]interface{}{"size": json.Number("5")}},
+	}}}, manifest)
+
+	_, err = container.ParseManifest([]byte("unknown"), unmarshal)
+	assert.EqualError(t, err, `container: cannot parse the manifest: json: unknown field "kind"`)
+
+	_, err = container.ParseManifest([]byte("bindings: ["), unmarshal)
+	assert.EqualError(t, err, "container: cannot parse the manifest: invalid document")
+}
+
+func TestReadManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"bindings": [{"factory": "test.cache.memory"}]}`), 0o600))
+
+	manifest, err := container.ReadManifest(path, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []container.ManifestBinding{{Factory: "test.cache.memory"}}, manifest.Bindings)
+
+	_, err = container.ReadManifest(filepath.Join(t.TempDir(), "missing.json"), nil)
+	assert.Error(t, err)
+}
+
+func TestContainer_Load(t *testing.T) {
+	c := container.New()
+
+	manifest, err := container.ParseManifest([]byte(`{"bindings": [
+		{"factory": "test.service"},
+		{"factory": "test.cache.redis", "params": {"address": "localhost:6379", "pool": {"size": 5}}},
+		{"factory": "test.cache.memory", "name": "local", "lifetime": "transient"}
+	]}`), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Load(manifest))
+
+	var service *CacheService
+	assert.NoError(t, c.Resolve(&service))
+	assert.Equal(t, &RedisCache{Address: "localhost:6379", Pool: 5}, service.cache)
+
+	var local Cache
+	assert.NoError(t, c.NamedResolve(&local, "local"))
+	assert.Equal(t, "memory", local.Name())
+
+	infos := c.Bindings(container.NamedBindings("local"))
+	assert.Equal(t, container.TransientLifetime, infos[0].Lifetime)
+}
+
+func TestContainer_Load_With_Invalid_Manifest(t *testing.T) {
+	c := container.New()
+
+	err := c.Load(container.Manifest{Bindings: []container.ManifestBinding{
+		{Factory: "test.cache.memory"},
+		{Factory: "test.unknown"},
+		{Factory: "test.cache.memory", Lifetime: "scoped", Name: "a"},
+		{Factory: "test.cache.memory", Type: "cache.Cache", Name: "b"},
+		{Factory: "test.cache.memory", Params: map[string]interface{}{"size": 1}, Name: "c"},
+		{Factory: "test.cache.redis"},
+	}})
+	assert.EqualError(t, err, `container: manifest binding 1 (test.unknown) has no registered factory
+container: manifest binding 2 (test.cache.memory) has an invalid lifetime "scoped" - it must be singleton or transient
+container: manifest binding 3 (test.cache.memory) returns container_test.Cache, not cache.Cache
+container: manifest binding 4 (test.cache.memory) has params, but the factory does not take them
+container: manifest binding 5 (test.cache.redis) binds container_test.Cache again`)
+	assert.Empty(t, c.Bindings())
+
+	err = c.Load(container.Manifest{Bindings: []container.ManifestBinding{{Factory: "test.service"}}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container: no concrete found for: container_test.Cache")
+	assert.Empty(t, c.Bindings())
+}
+
+func TestContainer_Load_With_Failing_Resolver(t *testing.T) {
+	c := container.New()
+
+	err := c.Load(container.Manifest{Bindings: []container.ManifestBinding{
+		{Factory: "test.cache.memory"},
+		{Factory: "test.failing"},
+	}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container: cannot bind manifest binding 1 (test.failing)")
+	assert.Contains(t, err.Error(), "app: unavailable")
+	assert.Empty(t, c.Bindings())
+}
+
+func TestContainer_Load_With_Failing_Resolver_It_Should_Keep_Other_Bindings(t *testing.T) {
+	c := container.New()
+
+	memory := MemoryCache{}
+	assert.NoError(t, container.InstanceAs[Cache](c, memory))
+
+	err := c.Singleton(func() Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	starts := 0
+	assert.NoError(t, container.OnStart(c, func(ctx context.Context, d Database) error {
+		starts++
+		return nil
+	}))
+	assert.NoError(t, c.Start(context.Background()))
+
+	err = c.Load(container.Manifest{Bindings: []container.ManifestBinding{
+		{Factory: "test.cache.redis", Params: map[string]interface{}{"address": "localhost:6379"}},
+		{Factory: "test.failing"},
+	}})
+	assert.Error(t, err)
+	assert.Len(t, c.Bindings(), 2)
+
+	var cache Cache
+	assert.NoError(t, c.Resolve(&cache))
+	assert.Equal(t, memory, cache)
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Equal(t, 1, starts)
+}
+
+func TestContainer_Load_With_RejectDuplicates_Policy(t *testing.T) {
+	c := container.New(container.WithDuplicatePolicy(container.RejectDuplicates))
+
+	err := c.Singleton(func() Cache {
+		return MemoryCache{}
+	})
+	assert.NoError(t, err)
+
+	err = c.Load(container.Manifest{Bindings: []container.ManifestBinding{{Factory: "test.cache.memory"}}})
+	assert.EqualError(t, err,
+		"container: manifest binding 0 (test.cache.memory) binds container_test.Cache that is already bound")
+}
+
+func TestContainer_Load_With_Built_Container(t *testing.T) {
+	c, err := container.New().Build()
+	assert.NoError(t, err)
+
+	assert.Error(t, c.Load(container.Manifest{}))
+}
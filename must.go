@@ -56,6 +56,34 @@ func MustNamedTransientLazy(c Container, name string, resolver interface{}) {
 	}
 }
 
+// MustInstance wraps the `Instance` method and panics on errors instead of returning the errors.
+func MustInstance(c Container, value interface{}) {
+	if err := c.Instance(value); err != nil {
+		panic(err)
+	}
+}
+
+// MustNamedInstance wraps the `NamedInstance` method and panics on errors instead of returning the errors.
+func MustNamedInstance(c Container, name string, value interface{}) {
+	if err := c.NamedInstance(name, value); err != nil {
+		panic(err)
+	}
+}
+
+// MustFactory wraps the `Factory` method and panics on errors instead of returning the errors.
+func MustFactory(c Container, parameters int, resolver interface{}) {
+	if err := c.Factory(parameters, resolver); err != nil {
+		panic(err)
+	}
+}
+
+// MustNamedFactory wraps the `NamedFactory` method and panics on errors instead of returning the errors.
+func MustNamedFactory(c Container, name string, parameters int, resolver interface{}) {
+	if err := c.NamedFactory(name, parameters, resolver); err != nil {
+		panic(err)
+	}
+}
+
 // MustCall wraps the `Call` method and panics on errors instead of returning the errors.
 func MustCall(c Container, receiver interface{}) {
 	if err := c.Call(receiver); err != nil {
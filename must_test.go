@@ -2,9 +2,11 @@ package container_test
 
 import (
 	"errors"
+	"runtime"
 	"testing"
 
-	"github.com/golobby/container/v3"
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMustSingleton_It_Should_Panic_On_Error(t *testing.T) {
@@ -97,6 +99,38 @@ func TestMustNamedTransientLazy_It_Should_Panic_On_Error(t *testing.T) {
 	t.Errorf("panic expcted.")
 }
 
+func TestMustInstance_It_Should_Panic_On_Error(t *testing.T) {
+	c := container.New()
+
+	defer func() { recover() }()
+	container.MustInstance(c, nil)
+	t.Errorf("panic expcted.")
+}
+
+func TestMustNamedInstance_It_Should_Panic_On_Error(t *testing.T) {
+	c := container.New()
+
+	defer func() { recover() }()
+	container.MustNamedInstance(c, "name", nil)
+	t.Errorf("panic expcted.")
+}
+
+func TestMustFactory_It_Should_Panic_On_Error(t *testing.T) {
+	c := container.New()
+
+	defer func() { recover() }()
+	container.MustFactory(c, 0, func() {})
+	t.Errorf("panic expcted.")
+}
+
+func TestMustNamedFactory_It_Should_Panic_On_Error(t *testing.T) {
+	c := container.New()
+
+	defer func() { recover() }()
+	container.MustNamedFactory(c, "name", 0, func() {})
+	t.Errorf("panic expcted.")
+}
+
 func TestMustCall_It_Should_Panic_On_Error(t *testing.T) {
 	c := container.New()
 
@@ -138,3 +172,22 @@ func TestMustFill_It_Should_Panic_On_Error(t *testing.T) {
 	container.MustFill(c, &myApp)
 	t.Errorf("panic expcted.")
 }
+
+func TestMustSingleton_It_Should_Record_Caller_Site(t *testing.T) {
+	c := container.New(container.WithDuplicatePolicy(container.RejectDuplicates))
+
+	_, file, line, _ := runtime.Caller(0)
+	container.MustSingleton(c, func() Shape {
+		return &Circle{a: 13}
+	})
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+
+	var duplicate *container.DuplicateError
+	assert.ErrorAs(t, err, &duplicate)
+	assert.Equal(t, file, duplicate.Existing.File)
+	assert.Equal(t, line+1, duplicate.Existing.Line)
+	assert.Equal(t, "github.com/golobby/container/v4_test.TestMustSingleton_It_Should_Record_Caller_Site", duplicate.Existing.Function)
+}
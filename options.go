@@ -0,0 +1,125 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DuplicatePolicy determines what the Container does when an abstraction with the same name is bound again.
+type DuplicatePolicy int
+
+const (
+	// AllowDuplicates replaces the existing binding silently.
+	AllowDuplicates DuplicatePolicy = iota
+	// WarnDuplicates replaces the existing binding and reports the duplicate to the duplicate hook.
+	WarnDuplicates
+	// RejectDuplicates keeps the existing binding and returns a DuplicateError.
+	RejectDuplicates
+)
+
+// options holds the settings of a Container.
+type options struct {
+	duplicatePolicy DuplicatePolicy       // duplicatePolicy is the policy for duplicate bindings.
+	duplicateHook   func(*DuplicateError) // duplicateHook receives the duplicates in the warn policy.
+	deferSingletons bool                  // deferSingletons is true if non-lazy singletons are made by Start.
+	workers         int                   // workers is the maximum number of resolvers that Start calls concurrently.
+	hookTimeout     time.Duration         // hookTimeout is the timeout of each lifecycle hook.
+	healthTimeout   time.Duration         // healthTimeout is the timeout of each health check.
+	configs         []ConfigSource        // configs are the sources of the config values (see WithConfig).
+
+	// stub makes the stubs of the missing bindings in the test mode (see WithStubs).
+	stub func(abstraction reflect.Type, name string) (interface{}, bool)
+}
+
+// Option configures a Container created by New.
+type Option func(*options)
+
+// WithDuplicatePolicy sets the policy for binding an abstraction with the same name more than once.
+// The default policy is AllowDuplicates.
+func WithDuplicatePolicy(policy DuplicatePolicy) Option {
+	return func(o *options) {
+		o.duplicatePolicy = policy
+	}
+}
+
+// WithDuplicateHook sets the function that receives duplicate bindings in the WarnDuplicates policy.
+// The duplicates are logged with the standard logger if there is no hook.
+func WithDuplicateHook(hook func(*DuplicateError)) Option {
+	return func(o *options) {
+		o.duplicateHook = hook
+	}
+}
+
+// WithDeferredSingletons defers making the non-lazy singletons to the Start method,
+// which makes them concurrently in the dependency order instead of one at a time at the binding time.
+func WithDeferredSingletons() Option {
+	return func(o *options) {
+		o.deferSingletons = true
+	}
+}
+
+// WithWorkers sets the maximum number of resolvers that the Start method calls concurrently.
+// The default number is runtime.GOMAXPROCS(0).
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		o.workers = workers
+	}
+}
+
+// defaultHookTimeout is the default timeout of each lifecycle hook.
+const defaultHookTimeout = 15 * time.Second
+
+// WithHookTimeout sets the timeout of each lifecycle hook, like the Start and Stop methods of the components.
+// The default timeout is 15 seconds.
+func WithHookTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.hookTimeout = timeout
+	}
+}
+
+// defaultHealthTimeout is the default timeout of each health check.
+const defaultHealthTimeout = 5 * time.Second
+
+// WithHealthTimeout sets the timeout of each health check (see Health).
+// The default timeout is 5 seconds.
+func WithHealthTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.healthTimeout = timeout
+	}
+}
+
+// WithConfig adds the sources of the configuration values that the config tags and BindConfig read.
+// The sources are looked up in the given order, so the first one that has a key provides its value,
+// e.g. `WithConfig(FlagSource(nil), EnvSource("APP"), file)` lets flags override the environment and the file.
+func WithConfig(sources ...ConfigSource) Option {
+	return func(o *options) {
+		o.configs = append(o.configs, sources...)
+	}
+}
+
+// WithStubs enables the test mode that resolves the missing bindings with stubs instead of failing.
+// The Container calls the function for the abstractions that resolver and receiver arguments, Resolve, and Fill need
+// but are not bound, and it uses the returned stub if the second result is true (see containertest.Stubs).
+// The optional fields of Fill are not stubbed.
+func WithStubs(stub func(abstraction reflect.Type, name string) (interface{}, bool)) Option {
+	return func(o *options) {
+		o.stub = stub
+	}
+}
+
+// DuplicateError is the error of binding an abstraction with a name that is already bound.
+type DuplicateError struct {
+	Abstraction reflect.Type // Abstraction is the duplicated abstraction.
+	Name        string       // Name is the duplicated binding name.
+	Existing    Site         // Existing is where the existing binding is made.
+	Duplicate   Site         // Duplicate is where the duplicate binding is made.
+}
+
+// Error returns the error message including the sites of both bindings.
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf(
+		"container: %s (name: %q) is already bound at %s, and bound again at %s",
+		e.Abstraction.String(), e.Name, e.Existing, e.Duplicate,
+	)
+}
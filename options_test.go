@@ -0,0 +1,174 @@
+package container_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// line returns the current line number of the caller.
+func line() int {
+	_, _, l, _ := runtime.Caller(1)
+	return l
+}
+
+func TestNew_With_Default_Duplicate_Policy(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 1}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() Shape {
+		return &Circle{a: 2}
+	})
+	assert.NoError(t, err)
+
+	var s Shape
+	assert.NoError(t, c.Resolve(&s))
+	assert.Equal(t, 2, s.GetArea())
+}
+
+func TestNew_With_RejectDuplicates_Policy(t *testing.T) {
+	c := container.New(container.WithDuplicatePolicy(container.RejectDuplicates))
+
+	first := line() + 1
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 1}
+	})
+	assert.NoError(t, err)
+
+	second := line() + 1
+	err = c.Transient(func() Shape {
+		return &Circle{a: 2}
+	})
+
+	var duplicate *container.DuplicateError
+	assert.True(t, errors.As(err, &duplicate))
+	assert.Equal(t, first, duplicate.Existing.Line)
+	assert.Equal(t, second, duplicate.Duplicate.Line)
+	assert.EqualError(t, err, fmt.Sprintf(
+		"container: container_test.Shape (name: \"\") is already bound at %s, and bound again at %s",
+		duplicate.Existing, duplicate.Duplicate,
+	))
+
+	var s Shape
+	assert.NoError(t, c.Resolve(&s))
+	assert.Equal(t, 1, s.GetArea())
+
+	err = c.NamedSingleton("rounded", func() Shape {
+		return &Circle{a: 3}
+	})
+	assert.NoError(t, err)
+
+	err = c.Instance(&MySQL{})
+	assert.NoError(t, err)
+
+	err = c.Instance(&MySQL{})
+	assert.Error(t, err)
+
+	err = c.Factory(1, func(a int) *Circle {
+		return &Circle{a: a}
+	})
+	assert.NoError(t, err)
+
+	err = c.Factory(1, func(a int) *Circle {
+		return &Circle{a: a}
+	})
+	assert.Error(t, err)
+
+	err = c.Singleton(func() (Shape, Database) {
+		return &Circle{}, &MySQL{}
+	})
+	assert.Error(t, err)
+}
+
+func TestNew_With_WarnDuplicates_Policy(t *testing.T) {
+	var duplicates []*container.DuplicateError
+	c := container.New(
+		container.WithDuplicatePolicy(container.WarnDuplicates),
+		container.WithDuplicateHook(func(err *container.DuplicateError) {
+			duplicates = append(duplicates, err)
+		}),
+	)
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 1}
+	})
+	assert.NoError(t, err)
+	assert.Len(t, duplicates, 0)
+
+	err = c.Singleton(func() Shape {
+		return &Circle{a: 2}
+	})
+	assert.NoError(t, err)
+	assert.Len(t, duplicates, 1)
+
+	var s Shape
+	assert.NoError(t, c.Resolve(&s))
+	assert.Equal(t, 2, s.GetArea())
+}
+
+func TestContainer_Override(t *testing.T) {
+	c := container.New(container.WithDuplicatePolicy(container.RejectDuplicates))
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 1}
+	})
+	assert.NoError(t, err)
+
+	err = c.Override().Singleton(func() Shape {
+		return &Circle{a: 2}
+	})
+	assert.NoError(t, err)
+
+	var s Shape
+	assert.NoError(t, c.Resolve(&s))
+	assert.Equal(t, 2, s.GetArea())
+
+	err = c.Singleton(func() Shape {
+		return &Circle{a: 3}
+	})
+	assert.Error(t, err)
+}
+
+func TestNew_With_Stubs(t *testing.T) {
+	var stubbed []string
+	c := container.New(container.WithStubs(func(abstraction reflect.Type, name string) (interface{}, bool) {
+		stubbed = append(stubbed, abstraction.String()+":"+name)
+		if abstraction == shapeType {
+			return &Circle{a: 42}, true
+		}
+		return "wrong type", true
+	}))
+
+	var s Shape
+	assert.NoError(t, c.NamedResolve(&s, "stub"))
+	assert.Equal(t, 42, s.GetArea())
+
+	err := c.Call(func(s Shape) {
+		assert.Equal(t, 42, s.GetArea())
+	})
+	assert.NoError(t, err)
+
+	filled := struct {
+		S Shape    `container:"name"`
+		D Database `container:"type,optional"`
+	}{}
+	assert.NoError(t, c.Fill(&filled))
+	assert.Equal(t, 42, filled.S.GetArea())
+	assert.Nil(t, filled.D)
+
+	var db Database
+	assert.EqualError(t, c.Resolve(&db), "container: no concrete found for: container_test.Database")
+
+	assert.Equal(t, []string{
+		"container_test.Shape:stub", "container_test.Shape:", "container_test.Shape:S", "container_test.Database:",
+	}, stubbed)
+}
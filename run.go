@@ -0,0 +1,76 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// runOptions holds the settings of the Run method.
+type runOptions struct {
+	signals         []os.Signal   // signals are the signals that stop the application.
+	shutdownTimeout time.Duration // shutdownTimeout is the deadline of stopping and disposing the components.
+}
+
+// RunOption configures the Run method.
+type RunOption func(*runOptions)
+
+// WithSignals sets the signals that stop the application.
+// The default signals are SIGINT and SIGTERM.
+func WithSignals(signals ...os.Signal) RunOption {
+	return func(o *runOptions) {
+		o.signals = signals
+	}
+}
+
+// WithShutdownTimeout sets the deadline of stopping and disposing the components.
+// The default timeout is 30 seconds.
+func WithShutdownTimeout(timeout time.Duration) RunOption {
+	return func(o *runOptions) {
+		o.shutdownTimeout = timeout
+	}
+}
+
+// Run starts the application and blocks until it receives a signal or the context is done.
+// It starts the components (see Start), then stops them (see Stop) and disposes the singletons (see Dispose)
+// in the reverse order within the shutdown timeout. It returns nil when the application is stopped gracefully.
+func (c Container) Run(ctx context.Context, opts ...RunOption) error {
+	o := &runOptions{signals: []os.Signal{os.Interrupt, syscall.SIGTERM}, shutdownTimeout: 30 * time.Second}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	shutdown := func() error {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), o.shutdownTimeout)
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() {
+			stopErr := c.Stop(ctx)
+			done <- errors.Join(stopErr, c.Dispose())
+		}()
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			return fmt.Errorf("container: cannot shut down within %s: %w", o.shutdownTimeout, ctx.Err())
+		}
+	}
+
+	notified, stop := signal.NotifyContext(ctx, o.signals...)
+	defer stop()
+
+	if err := c.Start(notified); err != nil {
+		return errors.Join(err, shutdown())
+	}
+
+	<-notified.Done()
+	stop()
+
+	return shutdown()
+}
@@ -0,0 +1,93 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Run(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.Singleton(func() *Component {
+		return &Component{name: "database", events: &events}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(db *Component) *Server {
+		return &Server{&Component{name: "server", events: &events}}
+	})
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	assert.NoError(t, container.OnStart(c, func(ctx context.Context, s *Server) error {
+		close(started)
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	assert.NoError(t, c.Run(ctx))
+	assert.Equal(t, []string{
+		"start database", "start server",
+		"stop server", "stop database",
+		"close server", "close database",
+	}, events)
+}
+
+func TestContainer_Run_With_Failed_Component_It_Should_Dispose(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.Singleton(func() *Component {
+		return &Component{name: "database", events: &events}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(db *Component) *Server {
+		return &Server{&Component{name: "server", events: &events, startErr: errors.New("port in use")}}
+	})
+	assert.NoError(t, err)
+
+	err = c.Run(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "port in use")
+	assert.Equal(t, []string{
+		"start database", "start server",
+		"stop database",
+		"close server", "close database",
+	}, events)
+}
+
+func TestContainer_Run_With_Shutdown_Timeout_It_Should_Fail(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.OnStop(c, func(ctx context.Context, s Shape) error {
+		time.Sleep(time.Second)
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err = c.Run(ctx, container.WithShutdownTimeout(20*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "container: cannot shut down within 20ms")
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
@@ -0,0 +1,29 @@
+//go:build unix
+
+package container_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Run_With_Signal(t *testing.T) {
+	c := container.New()
+
+	var events []string
+	err := c.Singleton(func() *Component {
+		return &Component{name: "database", events: &events}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, container.OnStart(c, func(ctx context.Context, db *Component) error {
+		return syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	}))
+
+	assert.NoError(t, c.Run(context.Background(), container.WithSignals(syscall.SIGUSR1)))
+	assert.Equal(t, []string{"start database", "stop database", "close database"}, events)
+}
@@ -0,0 +1,118 @@
+package container_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Scope(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func(s Shape) Database {
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	scope := c.Scope()
+
+	err = scope.Singleton(func(s Shape) *Service {
+		return &Service{s: s}
+	})
+	assert.NoError(t, err)
+
+	var service *Service
+	assert.NoError(t, scope.Resolve(&service))
+	assert.Equal(t, 13, service.s.GetArea())
+
+	var another *Service
+	assert.NoError(t, scope.Resolve(&another))
+	assert.Same(t, service, another)
+
+	assert.True(t, scope.Has(shapeType, ""))
+	assert.False(t, c.Has(reflect.TypeOf(&Service{}), ""))
+	assert.Len(t, scope.Bindings(), 1)
+
+	err = c.Resolve(&service)
+	assert.EqualError(t, err, "container: no concrete found for: *container_test.Service")
+
+	var d Database
+	assert.NoError(t, scope.Resolve(&d))
+	assert.NoError(t, scope.Validate())
+}
+
+func TestContainer_Scope_With_Shadowed_Binding(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.SingletonLazy(func(s Shape) *Service {
+		return &Service{s: s}
+	})
+	assert.NoError(t, err)
+
+	scope := c.Scope()
+	err = scope.Singleton(func() Shape {
+		return &Circle{a: 42}
+	})
+	assert.NoError(t, err)
+
+	var s Shape
+	assert.NoError(t, scope.Resolve(&s))
+	assert.Equal(t, 42, s.GetArea())
+
+	var service *Service
+	assert.NoError(t, scope.Resolve(&service))
+	assert.Equal(t, 13, service.s.GetArea(), "parent singletons must not depend on scoped bindings")
+
+	assert.NoError(t, c.Resolve(&s))
+	assert.Equal(t, 13, s.GetArea())
+}
+
+func TestContainer_Scope_Dispose(t *testing.T) {
+	c := container.New()
+
+	parent := &Closable{}
+	assert.NoError(t, c.Instance(parent))
+
+	scope := c.Scope()
+	scoped := &Closable{}
+	assert.NoError(t, container.InstanceAs[Shape](scope, &Circle{a: 13}))
+	assert.NoError(t, container.NamedInstanceAs(scope, "scoped", scoped))
+
+	assert.NoError(t, scope.Dispose())
+	assert.True(t, scoped.closed)
+	assert.False(t, parent.closed)
+}
+
+func TestContainer_Scope_Build(t *testing.T) {
+	c := container.New()
+
+	err := c.Singleton(func() Shape {
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	scope := c.Scope()
+	err = scope.TransientLazy(func(s Shape) *Service {
+		return &Service{s: s}
+	})
+	assert.NoError(t, err)
+
+	built, err := scope.Build()
+	assert.NoError(t, err)
+
+	var service *Service
+	assert.NoError(t, built.Resolve(&service))
+	assert.Equal(t, 13, service.s.GetArea())
+}
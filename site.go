@@ -0,0 +1,43 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// packagePath is the import path of the package, used to skip its own frames in call stacks.
+var packagePath = reflect.TypeOf(binding{}).PkgPath()
+
+// Site is the location in the code where a binding is made.
+// It is the first caller outside the package, so the bindings made by the global and Must helpers
+// point to the code that calls the helpers.
+type Site struct {
+	File     string // File is the source file path.
+	Line     int    // Line is the line number in the source file.
+	Function string // Function is the fully qualified name of the calling function.
+}
+
+// String returns the site in the "file:line (function)" format.
+func (s Site) String() string {
+	if s.Function == "" {
+		return fmt.Sprintf("%s:%d", s.File, s.Line)
+	}
+	return fmt.Sprintf("%s:%d (%s)", s.File, s.Line, s.Function)
+}
+
+// callerSite returns the site of the first caller outside the package.
+func callerSite() Site {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") {
+			return Site{File: frame.File, Line: frame.Line, Function: frame.Function}
+		}
+		if !more {
+			return Site{}
+		}
+	}
+}
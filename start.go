@@ -0,0 +1,160 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// task is a singleton that the Start method makes.
+type task struct {
+	binding    *binding // binding is the singleton binding, the source for resolvers of many abstractions.
+	label      string   // label is the abstraction type and name used in errors.
+	dependents []int    // dependents are the tasks that depend on the task.
+	pending    int      // pending is the number of the dependencies tasks that are not done yet.
+}
+
+// tasks returns the non-lazy singletons that are not made yet, and links them to their dependencies.
+// The dependencies are found through the bindings that are not tasks, like lazy and transient ones.
+func (c Container) tasks() []*task {
+	var tasks []*task
+	index := map[*binding]int{}
+
+	for _, info := range c.Bindings() {
+		b := c.bindings[info.Type][info.Name]
+		if b.source != nil {
+			b = b.source
+		}
+
+		if _, exist := index[b]; exist || !b.isSingleton || b.isLazy || b.isMade.Load() {
+			continue
+		}
+
+		index[b] = len(tasks)
+		tasks = append(tasks, &task{binding: b, label: label(info.Type, info.Name)})
+	}
+
+	for i, t := range tasks {
+		seen := map[*binding]bool{}
+
+		var walk func(b *binding)
+		walk = func(b *binding) {
+			deps, _ := dependencies(b.resolver)
+			for _, dep := range deps {
+				dependency, exist := c.bindings[dep.Type][dep.Name]
+				if !exist {
+					continue
+				}
+				if dependency.source != nil {
+					dependency = dependency.source
+				}
+
+				if seen[dependency] {
+					continue
+				}
+				seen[dependency] = true
+
+				if j, exist := index[dependency]; exist {
+					tasks[j].dependents = append(tasks[j].dependents, i)
+					t.pending++
+					continue
+				}
+
+				walk(dependency)
+			}
+		}
+		walk(t.binding)
+	}
+
+	return tasks
+}
+
+// Start makes the non-lazy singletons that are not made yet, like the ones that WithDeferredSingletons defers,
+// and then starts the components (see Starter and OnStart).
+// It validates the bindings first (see Validate), then calls the resolvers in the dependency order.
+// The resolvers that do not depend on each other are called concurrently, up to the workers limit (see WithWorkers).
+// The singletons that depend on failed ones are skipped, and the errors of all the failed ones are returned joined.
+// It stops calling new resolvers and returns without waiting for the running ones when the context is done.
+func (c Container) Start(ctx context.Context) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.makeSingletons(ctx); err != nil {
+		return err
+	}
+
+	return c.startComponents(ctx)
+}
+
+// makeSingletons makes the non-lazy singletons that are not made yet concurrently in the dependency order.
+func (c Container) makeSingletons(ctx context.Context) error {
+	tasks := c.tasks()
+
+	workers := c.settings().workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type result struct {
+		index int
+		err   error
+	}
+	results := make(chan result, len(tasks))
+
+	var ready []int
+	for i, t := range tasks {
+		if t.pending == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	errs := make([]error, len(tasks))
+	running, done := 0, 0
+	for done < len(tasks) {
+		for len(ready) > 0 && running < workers && ctx.Err() == nil {
+			i := ready[0]
+			ready = ready[1:]
+			running++
+
+			go func(i int) {
+				_, err := tasks[i].binding.make(c)
+				results <- result{index: i, err: err}
+			}(i)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		select {
+		case r := <-results:
+			running--
+			done++
+
+			if r.err != nil {
+				t := tasks[r.index]
+				errs[r.index] = fmt.Errorf(
+					"container: encountered error while making concrete for: %s (bound at %s). Error encountered: %w",
+					t.label, t.binding.site, r.err,
+				)
+				continue
+			}
+
+			for _, j := range tasks[r.index].dependents {
+				if tasks[j].pending--; tasks[j].pending == 0 {
+					ready = append(ready, j)
+				}
+			}
+		case <-ctx.Done():
+			running = 0
+		}
+	}
+
+	if done < len(tasks) && ctx.Err() != nil {
+		errs = append(errs, ctx.Err())
+	}
+
+	return errors.Join(errs...)
+}
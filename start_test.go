@@ -0,0 +1,269 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golobby/container/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Start_With_Deferred_Singletons(t *testing.T) {
+	c := container.New(container.WithDeferredSingletons())
+
+	calls := 0
+	err := c.Singleton(func() Shape {
+		calls++
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() (Database, *Config) {
+		return &MySQL{}, &Config{}
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, calls)
+	assert.Empty(t, c.Bindings(container.ResolvedBindings))
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Equal(t, 1, calls)
+	assert.Len(t, c.Bindings(container.ResolvedBindings), 3)
+
+	var s Shape
+	assert.NoError(t, c.Resolve(&s))
+	assert.Equal(t, 13, s.GetArea())
+	assert.Equal(t, 1, calls)
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Equal(t, 1, calls)
+}
+
+func TestContainer_Start_It_Should_Make_Independent_Singletons_Concurrently(t *testing.T) {
+	c := container.New(container.WithDeferredSingletons(), container.WithWorkers(2))
+
+	var started sync.WaitGroup
+	started.Add(2)
+	wait := func() error {
+		started.Done()
+
+		done := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(time.Second):
+			return errors.New("the other resolver is not called concurrently")
+		}
+	}
+
+	err := c.Singleton(func() (Shape, error) {
+		return &Circle{a: 13}, wait()
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() (Database, error) {
+		return &MySQL{}, wait()
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Start(context.Background()))
+}
+
+func TestContainer_Start_It_Should_Make_Dependencies_First(t *testing.T) {
+	c := container.New(container.WithDeferredSingletons(), container.WithWorkers(4))
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+
+	err := c.Singleton(func(r *Repository) *Service {
+		record("service")
+		return &Service{d: r.db}
+	})
+	assert.NoError(t, err)
+
+	err = c.TransientLazy(func(db Database) *Repository {
+		return &Repository{db: db}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func(config *Config) Database {
+		record("database")
+		return &MySQL{}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() *Config {
+		record("config")
+		return &Config{Host: "localhost"}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Equal(t, []string{"config", "database", "service"}, order)
+}
+
+func TestContainer_Start_It_Should_Limit_Workers(t *testing.T) {
+	c := container.New(container.WithDeferredSingletons(), container.WithWorkers(1))
+
+	var running, max int32
+	resolve := func() {
+		if n := atomic.AddInt32(&running, 1); n > atomic.LoadInt32(&max) {
+			atomic.StoreInt32(&max, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}
+
+	assert.NoError(t, c.Singleton(func() Shape { resolve(); return &Circle{} }))
+	assert.NoError(t, c.Singleton(func() Database { resolve(); return &MySQL{} }))
+	assert.NoError(t, c.Singleton(func() *Config { resolve(); return &Config{} }))
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.Equal(t, int32(1), max)
+}
+
+func TestContainer_Start_With_Resolver_Errors_It_Should_Aggregate_Them(t *testing.T) {
+	c := container.New(container.WithDeferredSingletons())
+
+	err := c.Singleton(func() (Shape, error) {
+		return nil, errors.New("shape error")
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() (Database, error) {
+		return nil, errors.New("database error")
+	})
+	assert.NoError(t, err)
+
+	called := false
+	err = c.Singleton(func(s Shape) *Service {
+		called = true
+		return &Service{s: s}
+	})
+	assert.NoError(t, err)
+
+	err = c.Singleton(func() *Config {
+		return &Config{Host: "localhost"}
+	})
+	assert.NoError(t, err)
+
+	err = c.Start(context.Background())
+	assert.Error(t, err)
+	assert.False(t, called)
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "container: encountered error while making concrete for: container_test.Database (bound at ")
+	assert.Contains(t, errs[0].Error(), "Error encountered: database error")
+	assert.Contains(t, errs[1].Error(), "container: encountered error while making concrete for: container_test.Shape (bound at ")
+	assert.Contains(t, errs[1].Error(), "Error encountered: shape error")
+
+	var config *Config
+	assert.NoError(t, c.Resolve(&config))
+	assert.Len(t, c.Bindings(container.ResolvedBindings), 1)
+}
+
+func TestContainer_Start_With_Resolver_Error_It_Should_Fail_Again(t *testing.T) {
+	c := container.New(container.WithDeferredSingletons())
+
+	fail := true
+	err := c.Singleton(func() (*MySQL, error) {
+		if fail {
+			return nil, errors.New("database error")
+		}
+		return &MySQL{}, nil
+	})
+	assert.NoError(t, err)
+
+	err = c.Start(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Error encountered: database error")
+
+	var db *MySQL
+	err = c.Resolve(&db)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Error encountered: database error")
+	assert.Nil(t, db)
+
+	err = c.Start(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Error encountered: database error")
+	assert.Empty(t, c.Bindings(container.ResolvedBindings))
+
+	fail = false
+	assert.NoError(t, c.Start(context.Background()))
+	assert.NoError(t, c.Resolve(&db))
+	assert.NotNil(t, db)
+}
+
+func TestContainer_Start_With_Missing_Dependency_It_Should_Fail(t *testing.T) {
+	c := container.New(container.WithDeferredSingletons())
+
+	err := c.Singleton(func(s Shape) *Service {
+		return &Service{s: s}
+	})
+	assert.NoError(t, err)
+
+	err = c.Start(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container: no concrete found for: container_test.Shape")
+}
+
+func TestContainer_Start_With_Canceled_Context_It_Should_Fail(t *testing.T) {
+	c := container.New(container.WithDeferredSingletons())
+
+	called := false
+	err := c.Singleton(func() Shape {
+		called = true
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = c.Start(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
+
+func TestContainer_Resolve_Concurrently_It_Should_Make_Singleton_Once(t *testing.T) {
+	c := container.New()
+
+	var calls int32
+	err := c.SingletonLazy(func() Shape {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(time.Millisecond)
+		return &Circle{a: 13}
+	})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var s Shape
+			assert.NoError(t, c.Resolve(&s))
+			assert.Equal(t, 13, s.GetArea())
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls)
+}